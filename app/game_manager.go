@@ -8,6 +8,7 @@ import (
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
 	"go.uber.org/zap"
 	"log"
+	"nextui-game-manager/cli"
 	"nextui-game-manager/models"
 	"nextui-game-manager/state"
 	"nextui-game-manager/ui"
@@ -35,11 +36,6 @@ const (
 )
 
 func init() {
-	gaba.InitSDL(gaba.GabagoolOptions{
-		WindowTitle:    "Game Manager",
-		ShowBackground: true,
-	})
-
 	common.SetLogLevel(defaultLogLevel)
 	common.InitIncludes()
 
@@ -53,6 +49,13 @@ func init() {
 
 	logger := common.GetLoggerInstance()
 	logger.Debug("Configuration loaded", zap.Object("config", config))
+}
+
+func initUI() {
+	gaba.InitSDL(gaba.GabagoolOptions{
+		WindowTitle:    "Game Manager",
+		ShowBackground: true,
+	})
 
 	collectionDir := utils.GetCollectionDirectory()
 	if _, err := os.Stat(collectionDir); os.IsNotExist(err) {
@@ -81,15 +84,85 @@ func loadConfig() (*models.Config, error) {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		exitCode := cli.Run(os.Args[1:])
+		utils.CloseGameTrackerDB()
+		common.CloseLogger()
+		os.Exit(exitCode)
+	}
+
+	initUI()
 	defer cleanup()
 
 	logger := common.GetLoggerInstance()
 	logger.Info("Starting Game Manager")
 
+	refreshLaunchCollections()
+	backupSavesOnLaunch()
+	purgeTrashOnLaunch()
+
 	runApplicationLoop()
 }
 
+// refreshLaunchCollections rebuilds the play history collections and the smart collections marked refresh_on_launch.
+func refreshLaunchCollections() {
+	logger := common.GetLoggerInstance()
+
+	autoCollections := state.GetAppState().Config.AutoCollections
+	if !autoCollections {
+		utils.RemoveAutoCollections()
+	}
+
+	smartCollections := utils.LaunchSmartCollections()
+	if !autoCollections && len(smartCollections) == 0 {
+		return
+	}
+
+	gaba.ProcessMessage("Refreshing collections...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		if autoCollections {
+			if _, err := utils.RefreshAutoCollections(time.Now()); err != nil {
+				logger.Error("Unable to refresh play history collections", zap.Error(err))
+			}
+		}
+
+		if _, err := utils.RefreshSmartCollections(smartCollections, time.Now()); err != nil {
+			logger.Error("Unable to refresh smart collections", zap.Error(err))
+		}
+		return nil, nil
+	})
+}
+
+// backupSavesOnLaunch backs up every save when the newest backup is older than the interval set in Settings.
+func backupSavesOnLaunch() {
+	config := state.GetAppState().Config
+	directory := utils.GetSaveBackupDirectory(config.SaveBackupDirectory)
+	if !utils.SaveBackupDue(directory, config.SaveBackupIntervalDays, time.Now()) {
+		return
+	}
+
+	gaba.ProcessMessage("Backing up saves...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		if _, err := utils.CreateSaveBackup(directory, config.SaveBackupKeep); err != nil {
+			common.GetLoggerInstance().Error("Unable to back up saves", zap.Error(err))
+		}
+		return nil, nil
+	})
+}
+
+// purgeTrashOnLaunch deletes for good the files that have been in the trash longer than Settings keeps them.
+func purgeTrashOnLaunch() {
+	freed, err := utils.PurgeExpiredTrash(state.GetAppState().Config.TrashRetentionDays, time.Now())
+	if err != nil {
+		common.GetLoggerInstance().Error("Unable to purge the trash", zap.Error(err))
+		return
+	}
+
+	if freed > 0 {
+		common.GetLoggerInstance().Info("Purged expired trash", zap.Int64("freed", freed))
+	}
+}
+
 func cleanup() {
+	utils.CloseGameTrackerDB()
 	gaba.CloseSDL()
 	common.CloseLogger()
 }
@@ -121,7 +194,7 @@ func handleScreenTransition(currentScreen models.Screen, result interface{}, cod
 	case models.ScreenNames.Tools:
 		return handleToolsTransition(result, code)
 	case models.ScreenNames.GlobalActions:
-		return handleGlobalActionsTransition(code)
+		return handleGlobalActionsTransition(result, code)
 	case models.ScreenNames.GamesList:
 		return handleGamesListTransition(currentScreen, result, code)
 	case models.ScreenNames.SearchBox:
@@ -156,8 +229,56 @@ func handleScreenTransition(currentScreen models.Screen, result interface{}, cod
 		return handlePlayHistoryGameDetailsTransition(currentScreen, result, code)
 	case models.ScreenNames.PlayHistoryGameHistory:
 		return handlePlayHistoryGameHistoryTransition(currentScreen, result, code)
+	case models.ScreenNames.PlayHistoryActions:
+		return handlePlayHistoryActionsTransition(currentScreen, result, code)
+	case models.ScreenNames.PlayHistoryStatistics:
+		return handlePlayHistoryStatisticsTransition()
+	case models.ScreenNames.YearInReview:
+		return handleYearInReviewTransition(result, code)
+	case models.ScreenNames.YearInReviewReport:
+		return handleYearInReviewReportTransition()
+	case models.ScreenNames.Backlog:
+		return handleBacklogTransition(result, code)
+	case models.ScreenNames.BacklogStatus:
+		return handleBacklogStatusTransition(currentScreen)
+	case models.ScreenNames.SmartCollections:
+		return handleSmartCollectionsTransition(code)
+	case models.ScreenNames.ManageSaves:
+		return handleManageSavesTransition(currentScreen, code)
+	case models.ScreenNames.SaveStates:
+		return handleSaveStatesTransition(currentScreen, code)
+	case models.ScreenNames.SaveBackups:
+		return handleSaveBackupsTransition(code)
+	case models.ScreenNames.OrphanedFiles:
+		return handleOrphanedFilesTransition(code)
+	case models.ScreenNames.OrphanedHistory:
+		return handleOrphanedHistoryTransition(currentScreen, result, code)
+	case models.ScreenNames.RehomeCandidates:
+		return handleRehomeCandidatesTransition(currentScreen)
 	case models.ScreenNames.PlayHistoryFilter:
 		return handlePlayHistoryFilterTransition(currentScreen, result, code)
+	case models.ScreenNames.OperationHistory:
+		return handleOperationHistoryTransition(result, code)
+	case models.ScreenNames.ValidateCollections:
+		return handleValidateCollectionsTransition(code)
+	case models.ScreenNames.VerifyRoms:
+		return handleVerifyRomsTransition(result, code)
+	case models.ScreenNames.DuplicateGroups:
+		return handleDuplicateGroupsTransition(result, code)
+	case models.ScreenNames.DuplicateGroupDetails:
+		return handleDuplicateGroupDetailsTransition(code)
+	case models.ScreenNames.OneGameOneRom:
+		return handleOneGameOneRomTransition(currentScreen)
+	case models.ScreenNames.StorageUsage:
+		return handleStorageUsageTransition(result, code)
+	case models.ScreenNames.StorageDetails:
+		return handleStorageDetailsTransition(result, code)
+	case models.ScreenNames.ImportPlayHistory:
+		return handleImportPlayHistoryTransition()
+	case models.ScreenNames.ImportCollection:
+		return handleImportCollectionTransition()
+	case models.ScreenNames.VerificationReport:
+		return handleVerificationReportTransition(currentScreen, code)
 	default:
 		state.ReturnToMain()
 		return ui.InitMainMenu()
@@ -245,7 +366,8 @@ func handlePlayHistoryGameHistoryTransition(currentScreen models.Screen, result
 
 	switch code {
 	case ExitCodeSuccess:
-		return ui.InitPlayHistoryGameHistoryScreen(ptghs.Console, ptghs.SearchFilter, ptghs.GameAggregate, 
+		state.AddNewMenuPosition()
+		return ui.InitPlayHistoryActionsScreen(ptghs.Console, ptghs.SearchFilter, ptghs.GameAggregate,
 			ptghs.Game, ptghs.RomDirectory, ptghs.PreviousRomDirectory, ptghs.PlayHistoryOrigin, ptghs.PlayHistoryFilterList)
 	case ExitCodeAction:
 		state.AddNewMenuPosition()
@@ -257,6 +379,92 @@ func handlePlayHistoryGameHistoryTransition(currentScreen models.Screen, result
 	}
 }
 
+func handlePlayHistoryActionsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	phas := currentScreen.(ui.PlayHistoryActionsScreen)
+
+	if code == ExitCodeSuccess {
+		switch models.ActionMap[result.(string)] {
+		case models.Actions.PlayHistoryAdopt:
+			state.AddNewMenuPosition()
+			return ui.InitOrphanedHistoryScreen(phas.GameAggregate.Id, &phas)
+		case models.Actions.PlayHistoryDelete:
+			return handleDeletePlayHistoryAction(phas)
+		}
+	}
+
+	state.RemoveMenuPositions(1)
+	return ui.InitPlayHistoryGameHistoryScreen(phas.Console, phas.SearchFilter, phas.GameAggregate,
+		phas.Game, phas.RomDirectory, phas.PreviousRomDirectory, phas.PlayHistoryOrigin, phas.PlayHistoryFilterList)
+}
+
+func handleDeletePlayHistoryAction(phas ui.PlayHistoryActionsScreen) models.Screen {
+	if !utils.ConfirmAction(fmt.Sprintf("Delete all play history of %s?", phas.GameAggregate.Name)) {
+		return phas
+	}
+
+	utils.BeginOperation(fmt.Sprintf("Delete Play History of %s", phas.GameAggregate.Name))
+	err := utils.DeletePlayHistory(phas.GameAggregate)
+	utils.CommitOperation()
+
+	state.ClearPlayMaps()
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to delete play history", zap.Error(err))
+		utils.ShowTimedMessage("Unable to delete play history!", standardMessageDelay)
+		return phas
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Deleted play history of %s!", phas.GameAggregate.Name), standardMessageDelay)
+
+	// details and actions
+	state.RemoveMenuPositions(2)
+	return leavePlayHistoryGame(phas)
+}
+
+// leavePlayHistoryGame returns to wherever the game's play details were opened from, once its history has changed
+func leavePlayHistoryGame(phas ui.PlayHistoryActionsScreen) models.Screen {
+	if phas.PlayHistoryOrigin {
+		return ui.InitPlayHistoryGamesListScreen(phas.Console, phas.PlayHistoryFilterList)
+	}
+	return ui.InitActionsScreen(phas.Game, phas.RomDirectory, phas.PreviousRomDirectory, phas.SearchFilter)
+}
+
+func handleOrphanedHistoryTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
+	ohs := currentScreen.(ui.OrphanedHistoryScreen)
+
+	switch code {
+	case ExitCodeSuccess:
+		state.AddNewMenuPosition()
+		return ui.InitRehomeCandidatesScreen(result.(models.OrphanedHistory), ohs.ActionsScreen)
+	case ExitCodeAction:
+		return ui.InitOrphanedHistoryScreen(ohs.RomIds, ohs.ActionsScreen)
+	}
+
+	if ohs.ActionsScreen == nil {
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+
+	if code == ExitCodeEmpty {
+		// every orphan of the game has been dealt with: orphans, actions and details
+		state.RemoveMenuPositions(3)
+		return leavePlayHistoryGame(*ohs.ActionsScreen)
+	}
+
+	state.RemoveMenuPositions(1)
+	return *ohs.ActionsScreen
+}
+
+func handleRehomeCandidatesTransition(currentScreen models.Screen) models.Screen {
+	rcs := currentScreen.(ui.RehomeCandidatesScreen)
+
+	state.RemoveMenuPositions(1)
+	if rcs.ActionsScreen == nil {
+		return ui.InitOrphanedHistoryScreen(nil, nil)
+	}
+	return ui.InitOrphanedHistoryScreen(rcs.ActionsScreen.GameAggregate.Id, rcs.ActionsScreen)
+}
+
 func handlePlayHistoryGameDetailsTransition(currentScreen models.Screen, result interface{}, code int) models.Screen {
 	ptgds := currentScreen.(ui.PlayHistoryGameDetailsScreen)
 	switch code {
@@ -460,6 +668,39 @@ func handleToolsTransition(result interface{}, code int) models.Screen {
 			return ui.InitGlobalActionsScreen()
 		case "Play History":
 			return ui.InitPlayHistoryListScreen(nil)
+		case "Play Statistics":
+			return ui.InitPlayHistoryStatisticsScreen()
+		case "Year in Review":
+			return ui.InitYearInReviewScreen()
+		case "Backlog":
+			return ui.InitBacklogScreen()
+		case "Smart Collections":
+			return ui.InitSmartCollectionsScreen()
+		case "Import Collection":
+			return ui.InitImportCollectionScreen()
+		case "Operation History":
+			return ui.InitOperationHistoryScreen()
+		case "Validate Collections":
+			return ui.InitValidateCollectionsScreen()
+		case "Verify ROMs":
+			return ui.InitVerifyRomsScreen()
+		case "Storage Usage":
+			return ui.InitStorageUsageScreen()
+		case "Orphaned Files":
+			return ui.InitOrphanedFilesScreen()
+		case "Orphaned Play History":
+			return ui.InitOrphanedHistoryScreen(nil, nil)
+		case "Import Play History":
+			return ui.InitImportPlayHistoryScreen()
+		case "Export Play History":
+			state.RemoveMenuPositions(1)
+			exportPlayHistory()
+		case "Undo Last Operation":
+			state.RemoveMenuPositions(1)
+			undoLastOperation()
+		case "Empty Trash":
+			state.RemoveMenuPositions(1)
+			emptyTrash()
 		}
 		return ui.InitToolsScreen()
 	case ExitCodeAction:
@@ -471,8 +712,314 @@ func handleToolsTransition(result interface{}, code int) models.Screen {
 	}
 }
 
-func handleGlobalActionsTransition(code int) models.Screen {
+func exportPlayHistory() {
+	logger := common.GetLoggerInstance()
+
+	var written []string
+	var err error
+	gaba.ProcessMessage("Exporting play history...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		var export models.PlayHistoryExport
+		export, err = utils.ExportPlayHistory()
+		if err == nil {
+			written, err = utils.SavePlayHistoryExport(export, utils.GetExportDirectory(), utils.PlayHistoryFormatJSON, utils.PlayHistoryFormatCSV)
+		}
+		return nil, nil
+	})
+
+	if err != nil {
+		logger.Error("Failed to export play history", zap.Error(err))
+		utils.ShowTimedMessage("Unable to export play history!", standardMessageDelay)
+		return
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Exported %d files to %s", len(written), utils.GetExportDirectory()), longMessageDelay)
+}
+
+func undoLastOperation() {
+	batch, found := utils.LastUndoableOperation()
+	if !found {
+		utils.ShowTimedMessage("Nothing to undo!", standardMessageDelay)
+		return
+	}
+
+	if utils.ConfirmAction(fmt.Sprintf("Undo %s?", batch.Description)) {
+		undoOperation(batch)
+	}
+}
+
+func undoOperation(batch models.JournalBatch) {
+	logger := common.GetLoggerInstance()
+
+	var err error
+	gaba.ProcessMessage(fmt.Sprintf("Undoing %s...", batch.Description), gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		err = utils.UndoOperation(batch.Id)
+		return nil, nil
+	})
+
+	state.ClearCollectionMap()
+	state.ClearPlayMaps()
+
+	if err != nil {
+		logger.Error("Failed to undo operation", zap.String("operation", batch.Description), zap.Error(err))
+		utils.ShowTimedMessage("Some changes could not be undone!\nRetry from Operation History.", longMessageDelay)
+		return
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Undid %s!", batch.Description), standardMessageDelay)
+}
+
+func emptyTrash() {
+	var size int64
+	gaba.ProcessMessage("Measuring trash...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		size = utils.TrashSize()
+		return nil, nil
+	})
+
+	if size == 0 {
+		utils.ShowTimedMessage("The trash is empty!", standardMessageDelay)
+		return
+	}
+
+	if !utils.ConfirmAction(fmt.Sprintf("Permanently delete %s in the trash?\nThose deletions can no longer be undone.", utils.FormatBytes(size))) {
+		return
+	}
+
+	var freed int64
+	var err error
+	gaba.ProcessMessage("Emptying trash...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		freed, err = utils.EmptyTrash()
+		return nil, nil
+	})
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to empty trash", zap.Error(err))
+		utils.ShowTimedMessage("Unable to empty the trash!", standardMessageDelay)
+		return
+	}
+
+	utils.RefreshStorageReport()
+	utils.ShowTimedMessage(fmt.Sprintf("Freed %s!", utils.FormatBytes(freed)), standardMessageDelay)
+}
+
+func handleOperationHistoryTransition(result interface{}, code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		batch := result.(models.JournalBatch)
+		if batch.Undone {
+			utils.ShowTimedMessage("This operation was already undone!", standardMessageDelay)
+		} else if utils.ConfirmAction(fmt.Sprintf("Undo %s?", batch.Description)) {
+			undoOperation(batch)
+		}
+		return ui.InitOperationHistoryScreen()
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+}
+
+func handleValidateCollectionsTransition(code int) models.Screen {
+	switch code {
+	case ExitCodeAction:
+		return ui.InitValidateCollectionsScreen()
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+}
+
+func handleVerifyRomsTransition(result interface{}, code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		state.AddNewMenuPosition()
+		return ui.InitVerificationReportScreen(result.(shared.RomDirectory))
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+}
+
+func handleVerificationReportTransition(currentScreen models.Screen, code int) models.Screen {
+	vrs := currentScreen.(ui.VerificationReportScreen)
+
+	switch code {
+	case ExitCodeAction:
+		return ui.InitVerificationReportScreen(vrs.RomDirectory)
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitVerifyRomsScreen()
+	}
+}
+
+func handleDuplicateGroupsTransition(result interface{}, code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		state.AddNewMenuPosition()
+		return ui.InitDuplicateGroupDetailsScreen(result.(models.DuplicateGroup))
+	case ExitCodeAction:
+		return ui.InitDuplicateGroupsScreen()
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitGlobalActionsScreen()
+	}
+}
+
+func handleDuplicateGroupDetailsTransition(code int) models.Screen {
+	state.RemoveMenuPositions(1)
+	return ui.InitDuplicateGroupsScreen()
+}
+
+func handleOneGameOneRomTransition(currentScreen models.Screen) models.Screen {
+	ogor := currentScreen.(ui.OneGameOneRomScreen)
+
+	state.RemoveMenuPositions(1)
+	if len(ogor.Games) == 0 {
+		return ui.InitGlobalActionsScreen()
+	}
+
+	return ui.InitGamesListWithPreviousDirectory(ogor.RomDirectory, ogor.PreviousRomDirectory, ogor.SearchFilter)
+}
+
+func handlePlayHistoryStatisticsTransition() models.Screen {
+	state.RemoveMenuPositions(1)
+	return ui.InitToolsScreen()
+}
+
+func handleYearInReviewTransition(result interface{}, code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		state.AddNewMenuPosition()
+		return ui.InitYearInReviewReportScreen(result.(int))
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+}
+
+func handleYearInReviewReportTransition() models.Screen {
+	state.RemoveMenuPositions(1)
+	return ui.InitYearInReviewScreen()
+}
+
+func handleBacklogTransition(result interface{}, code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		state.AddNewMenuPosition()
+		return ui.InitBacklogStatusScreen(result.(shared.Item), nil)
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+}
+
+func handleBacklogStatusTransition(currentScreen models.Screen) models.Screen {
+	bss := currentScreen.(ui.BacklogStatusScreen)
+	state.RemoveMenuPositions(1)
+
+	if bss.ActionsScreen != nil {
+		return *bss.ActionsScreen
+	}
+	return ui.InitBacklogScreen()
+}
+
+func handleManageSavesTransition(currentScreen models.Screen, code int) models.Screen {
+	mss := currentScreen.(ui.ManageSavesScreen)
+
+	if code == ExitCodeSuccess {
+		return ui.InitManageSavesScreen(mss.Game, mss.RomDirectory, mss.ActionsScreen)
+	}
+
+	state.RemoveMenuPositions(1)
+	return *mss.ActionsScreen
+}
+
+func handleSaveStatesTransition(currentScreen models.Screen, code int) models.Screen {
+	sss := currentScreen.(ui.SaveStatesScreen)
+
+	if code == ExitCodeSuccess {
+		return ui.InitSaveStatesScreen(sss.Game, sss.RomDirectory, sss.ActionsScreen)
+	}
+
+	state.RemoveMenuPositions(1)
+	return *sss.ActionsScreen
+}
+
+func handleSaveBackupsTransition(code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		return ui.InitSaveBackupsScreen()
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitGlobalActionsScreen()
+	}
+}
+
+func handleOrphanedFilesTransition(code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		return ui.InitOrphanedFilesScreen()
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+}
+
+func handleSmartCollectionsTransition(code int) models.Screen {
+	switch code {
+	case ExitCodeCancel:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	default:
+		return ui.InitSmartCollectionsScreen()
+	}
+}
+
+func handleImportPlayHistoryTransition() models.Screen {
+	state.RemoveMenuPositions(1)
+	return ui.InitToolsScreen()
+}
+
+func handleImportCollectionTransition() models.Screen {
+	state.RemoveMenuPositions(1)
+	return ui.InitToolsScreen()
+}
+
+func handleStorageUsageTransition(result interface{}, code int) models.Screen {
+	switch code {
+	case ExitCodeSuccess:
+		state.AddNewMenuPosition()
+		return ui.InitStorageDetailsScreen(result.(models.StorageUsage))
+	case ExitCodeAction:
+		return ui.InitStorageUsageScreen()
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitToolsScreen()
+	}
+}
+
+func handleStorageDetailsTransition(result interface{}, code int) models.Screen {
 	switch code {
+	case ExitCodeAction:
+		return ui.InitStorageDetailsScreen(result.(models.StorageUsage))
+	default:
+		state.RemoveMenuPositions(1)
+		return ui.InitStorageUsageScreen()
+	}
+}
+
+func handleGlobalActionsTransition(result interface{}, code int) models.Screen {
+	switch code {
+	case ExitCodeAction:
+		if result == models.Actions.GlobalFindDuplicates {
+			state.AddNewMenuPosition()
+			return ui.InitDuplicateGroupsScreen()
+		} else if result == models.Actions.GlobalRestoreSaves {
+			state.AddNewMenuPosition()
+			return ui.InitSaveBackupsScreen()
+		} else if romDirectory, ok := result.(shared.RomDirectory); ok {
+			state.AddNewMenuPosition()
+			return ui.InitOneGameOneRomScreen(romDirectory, nil, shared.RomDirectory{}, "")
+		}
+		return ui.InitGlobalActionsScreen()
 	case ExitCodeCancel:
 		state.RemoveMenuPositions(1)
 		return ui.InitToolsScreen()
@@ -601,6 +1148,17 @@ func executeGameAction(as ui.ActionsScreen, action sum.Int[models.Action]) model
 		return handleDeleteRomAction(as)
 	case models.Actions.Nuke:
 		return handleNukeAction(as)
+	case models.Actions.BacklogStatus:
+		state.AddNewMenuPosition()
+		return ui.InitBacklogStatusScreen(as.Game, &as)
+	case models.Actions.ManageSaves:
+		state.AddNewMenuPosition()
+		return ui.InitManageSavesScreen(as.Game, as.RomDirectory, &as)
+	case models.Actions.SaveStates:
+		state.AddNewMenuPosition()
+		return ui.InitSaveStatesScreen(as.Game, as.RomDirectory, &as)
+	case models.Actions.ClearSaveStates:
+		return handleClearSaveStatesAction(as)
 	case models.Actions.PlayHistoryOpen:
 		state.AddNewMenuPosition()
 		return ui.InitPlayHistoryGameDetailsScreenFromActions(as.Game, as.RomDirectory, as.PreviousRomDirectory, as.SearchFilter)
@@ -621,7 +1179,7 @@ func handleDeleteArtAction(as ui.ActionsScreen) models.Screen {
 	}
 
 	if confirmDeletion("Delete this beautiful art?", existingArtPath) {
-		common.DeleteFile(existingArtPath)
+		utils.DeleteArt(as.Game.Filename, as.RomDirectory)
 	}
 
 	return ui.InitActionsScreen(as.Game, as.RomDirectory, as.PreviousRomDirectory, as.SearchFilter)
@@ -639,7 +1197,8 @@ func handleRenameRomAction(as ui.ActionsScreen) models.Screen {
 	}
 
 	newFilename := newName.Unwrap()
-	newPath, err := utils.RenameRom(as.Game, newFilename, as.RomDirectory)
+	newPath, err := utils.RenameRom(as.Game, newFilename, as.RomDirectory, state.GetCollectionMap())
+	state.ClearCollectionMap()
 	if err != nil {
 		utils.ShowTimedMessage("Unable to rename ROM!", longMessageDelay)
 		return ui.InitActionsScreen(as.Game, as.RomDirectory, as.PreviousRomDirectory, as.SearchFilter)
@@ -651,6 +1210,23 @@ func handleRenameRomAction(as ui.ActionsScreen) models.Screen {
 	return ui.InitActionsScreen(as.Game, as.RomDirectory, as.PreviousRomDirectory, as.SearchFilter)
 }
 
+func handleClearSaveStatesAction(as ui.ActionsScreen) models.Screen {
+	message := fmt.Sprintf("Clear all save states of %s?", as.Game.DisplayName)
+	if !utils.ConfirmAction(message) {
+		return ui.InitActionsScreen(as.Game, as.RomDirectory, as.PreviousRomDirectory, as.SearchFilter)
+	}
+
+	cleared, err := utils.ClearSaveStates(as.Game, as.RomDirectory)
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to clear save states", zap.Error(err))
+		utils.ShowTimedMessage("Unable to clear save states!", longMessageDelay)
+	} else {
+		utils.ShowTimedMessage(fmt.Sprintf("Cleared %d save states!", cleared), longMessageDelay)
+	}
+
+	return ui.InitActionsScreen(as.Game, as.RomDirectory, as.PreviousRomDirectory, as.SearchFilter)
+}
+
 func handleClearGameTrackerAction(as ui.ActionsScreen) models.Screen {
 	message := fmt.Sprintf("Clear %s from Game Tracker?", as.Game.DisplayName)
 	if !utils.ConfirmAction(message) {
@@ -670,7 +1246,8 @@ func handleClearGameTrackerAction(as ui.ActionsScreen) models.Screen {
 func handleDeleteRomAction(as ui.ActionsScreen) models.Screen {
 	message := fmt.Sprintf("Delete %s?", as.Game.DisplayName)
 	if utils.ConfirmAction(message) {
-		utils.DeleteRom(as.Game, as.RomDirectory)
+		utils.DeleteRom(as.Game, as.RomDirectory, state.GetCollectionMap())
+		state.ClearCollectionMap()
 		//TODO: Update position around deleted rom
 		state.RemoveMenuPositions(1)
 		state.UpdateCurrentMenuPosition(0, 0)
@@ -683,7 +1260,8 @@ func handleDeleteRomAction(as ui.ActionsScreen) models.Screen {
 func handleNukeAction(as ui.ActionsScreen) models.Screen {
 	message := fmt.Sprintf("Nuke %s?", as.Game.DisplayName)
 	if utils.ConfirmAction(message) {
-		utils.Nuke(as.Game, as.RomDirectory)
+		utils.Nuke(as.Game, as.RomDirectory, state.GetCollectionMap())
+		state.ClearCollectionMap()
 		//TODO: Update position around deleted rom
 		state.RemoveMenuPositions(1)
 		state.UpdateCurrentMenuPosition(0, 0)
@@ -720,6 +1298,10 @@ func executeBulkAction(ba ui.BulkOptionsScreen, action sum.Int[models.Action]) m
 	case models.Actions.ArchiveRom:
 		state.AddNewMenuPosition()
 		return ui.InitAddToArchiveScreen(ba.Games, ba.RomDirectory, ba.PreviousRomDirectory, ba.SearchFilter)
+	case models.Actions.OneGameOneRom:
+		state.RemoveMenuPositions(1)
+		state.AddNewMenuPosition()
+		return ui.InitOneGameOneRomScreen(ba.RomDirectory, ba.Games, ba.PreviousRomDirectory, ba.SearchFilter)
 	case models.Actions.DeleteRom:
 		handleBulkDelete(ba)
 	case models.Actions.Nuke:
@@ -762,6 +1344,9 @@ func showArtDownloadResult(artPaths []string, totalGames int) {
 
 func handleBulkDeleteArt(ba ui.BulkOptionsScreen) {
 	if utils.ConfirmBulkAction("Delete art for the selected games?") {
+		utils.BeginOperation(fmt.Sprintf("Delete art for %d Games", len(ba.Games)))
+		defer utils.CommitOperation()
+
 		for _, game := range ba.Games {
 			utils.DeleteArt(game.Filename, ba.RomDirectory)
 		}
@@ -770,16 +1355,28 @@ func handleBulkDeleteArt(ba ui.BulkOptionsScreen) {
 
 func handleBulkDelete(ba ui.BulkOptionsScreen) {
 	if utils.ConfirmBulkAction("Delete the selected games?") {
+		utils.BeginOperation(fmt.Sprintf("Delete %d Games", len(ba.Games)))
+		defer utils.CommitOperation()
+
+		collectionMap := state.GetCollectionMap()
+		defer state.ClearCollectionMap()
+
 		for _, game := range ba.Games {
-			utils.DeleteRom(game, ba.RomDirectory)
+			utils.DeleteRom(game, ba.RomDirectory, collectionMap)
 		}
 	}
 }
 
 func handleBulkNuke(ba ui.BulkOptionsScreen) {
 	if utils.ConfirmBulkAction("Nuke the selected games?") {
+		utils.BeginOperation(fmt.Sprintf("Nuke %d Games", len(ba.Games)))
+		defer utils.CommitOperation()
+
+		collectionMap := state.GetCollectionMap()
+		defer state.ClearCollectionMap()
+
 		for _, game := range ba.Games {
-			utils.Nuke(game, ba.RomDirectory)
+			utils.Nuke(game, ba.RomDirectory, collectionMap)
 		}
 	}
 }
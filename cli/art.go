@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"slices"
+	"strings"
+)
+
+func runArt(args []string) int {
+	if len(args) == 0 || args[0] != "missing" {
+		return usageError("art missing [-download]")
+	}
+
+	flags := flag.NewFlagSet("art missing", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	download := flags.Bool("download", false, "download art for every ROM that is missing it")
+
+	if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 0 {
+		return usageError("art missing [-download]")
+	}
+
+	noArt, err := utils.FindRomsWithoutArt()
+	if err != nil {
+		return failure("Unable to scan for missing art: %v", err)
+	}
+
+	platforms := make([]shared.RomDirectory, 0, len(noArt))
+	for platform := range noArt {
+		platforms = append(platforms, platform)
+	}
+	slices.SortFunc(platforms, func(a, b shared.RomDirectory) int {
+		return strings.Compare(a.DisplayName, b.DisplayName)
+	})
+
+	config := state.GetAppState().Config
+	missing, found := 0, 0
+
+	for _, platform := range platforms {
+		for _, game := range noArt[platform] {
+			missing++
+
+			if !*download {
+				fmt.Fprintf(stdout, "%s\t%s\n", platform.DisplayName, game.Path)
+				continue
+			}
+
+			if artPath := utils.FindArt(platform, game, config.ArtDownloadType, config.FuzzySearchThreshold); artPath != "" {
+				found++
+				fmt.Fprintf(stdout, "Downloaded %s\n", artPath)
+			} else {
+				fmt.Fprintf(stdout, "No art found for %s\n", game.Path)
+			}
+		}
+	}
+
+	if *download {
+		fmt.Fprintf(stdout, "Art found for %d/%d games\n", found, missing)
+		if found < missing {
+			return ExitCodeNotFound
+		}
+	}
+
+	return ExitCodeSuccess
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	ExitCodeSuccess  = 0 // Command completed
+	ExitCodeError    = 1 // Command failed
+	ExitCodeUsage    = 2 // Bad arguments or unknown command
+	ExitCodeNotFound = 3 // Nothing matched the request
+)
+
+type command struct {
+	Name        string
+	Usage       string
+	Description string
+	Run         func(args []string) int
+}
+
+var stdout io.Writer = os.Stdout
+var stderr io.Writer = os.Stderr
+
+func commands() []command {
+	return []command{
+		{Name: "rename", Usage: "rename <rom-path> <new-name>", Description: "Rename a ROM along with its art, save file and game tracker data", Run: runRename},
+		{Name: "archive", Usage: "archive [-archive name] <rom-path>...", Description: "Move ROMs and their art into a hidden archive folder", Run: runArchive},
+		{Name: "restore", Usage: "restore <archived-rom-path>...", Description: "Move archived ROMs and their art back into the ROM directory", Run: runRestore},
+		{Name: "collection", Usage: "collection add <collection-name> <rom-path>...", Description: "Add ROMs to a collection, creating it if needed", Run: runCollection},
+		{Name: "art", Usage: "art missing [-download]", Description: "List ROMs without art, optionally downloading it", Run: runArt},
+		{Name: "history", Usage: "history <export|import> [arguments]", Description: "Export play history as JSON or CSV, or merge another device's export", Run: runHistory},
+	}
+}
+
+// Run executes a headless subcommand without touching SDL and returns the process exit code.
+func Run(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return ExitCodeSuccess
+	}
+
+	for _, cmd := range commands() {
+		if cmd.Name == args[0] {
+			return cmd.Run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(stderr, "Unknown command: %s\n\n", args[0])
+	printUsage(stderr)
+	return ExitCodeUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: game-manager <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-48s %s\n", cmd.Usage, cmd.Description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run without a command to start the on-device interface.")
+}
+
+func usageError(usage string) int {
+	fmt.Fprintf(stderr, "Usage: game-manager %s\n", usage)
+	return ExitCodeUsage
+}
+
+func failure(format string, args ...interface{}) int {
+	message := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+	fmt.Fprint(stderr, message)
+	return ExitCodeError
+}
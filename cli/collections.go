@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"strings"
+)
+
+func runCollection(args []string) int {
+	if len(args) < 3 || args[0] != "add" {
+		return usageError("collection add <collection-name> <rom-path>...")
+	}
+
+	collectionName := strings.TrimSpace(args[1])
+	if collectionName == "" || strings.Contains(collectionName, "/") {
+		return failure("Invalid collection name: %q", args[1])
+	}
+
+	var games []shared.Item
+	for _, romPath := range args[2:] {
+		game, _, err := utils.ResolveRomPath(romPath)
+		if err != nil {
+			return failure("%v", err)
+		}
+		games = append(games, game)
+	}
+
+	collection := models.Collection{
+		DisplayName:    collectionName,
+		CollectionFile: filepath.Join(utils.GetCollectionDirectory(), collectionName+".txt"),
+	}
+
+	collection, err := utils.AddCollectionGames(state.GetCollectionMap(), collection, games)
+	state.ClearCollectionMap()
+	if err != nil {
+		return failure("Unable to add games to %s: %v", collectionName, err)
+	}
+
+	fmt.Fprintf(stdout, "%s now contains %d games\n", collection.DisplayName, len(collection.Games))
+	return ExitCodeSuccess
+}
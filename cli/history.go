@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"nextui-game-manager/utils"
+	"os"
+	"strings"
+)
+
+const historyUsage = "history export [-format json|csv] [-output path] | history import <file>"
+
+func runHistory(args []string) int {
+	if len(args) == 0 {
+		return usageError(historyUsage)
+	}
+
+	switch args[0] {
+	case "export":
+		return runHistoryExport(args[1:])
+	case "import":
+		return runHistoryImport(args[1:])
+	}
+
+	return usageError(historyUsage)
+}
+
+// JSON goes to stdout unless -output is given; CSV is always two files, so -output names a directory.
+func runHistoryExport(args []string) int {
+	flags := flag.NewFlagSet("history export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", utils.PlayHistoryFormatJSON, "json or csv")
+	output := flags.String("output", "", "file to write JSON to, or directory to write CSV files to")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return usageError(historyUsage)
+	}
+
+	if *format != utils.PlayHistoryFormatJSON && *format != utils.PlayHistoryFormatCSV {
+		return usageError(historyUsage)
+	}
+
+	export, err := utils.ExportPlayHistory()
+	if err != nil {
+		return failure("Unable to read the game tracker database at %s: %v", utils.GetGameTrackerDBPath(), err)
+	}
+
+	if *format == utils.PlayHistoryFormatCSV {
+		directory := *output
+		if directory == "" {
+			directory = utils.GetExportDirectory()
+		}
+
+		written, err := utils.SavePlayHistoryExport(export, directory, utils.PlayHistoryFormatCSV)
+		if err != nil {
+			return failure("Unable to export play history: %v", err)
+		}
+
+		fmt.Fprintf(stdout, "Wrote play history to %s\n", strings.Join(written, ", "))
+		return ExitCodeSuccess
+	}
+
+	if *output == "" {
+		if err := utils.WritePlayHistoryJSON(stdout, export); err != nil {
+			return failure("Unable to encode play history: %v", err)
+		}
+		return ExitCodeSuccess
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return failure("Unable to write %s: %v", *output, err)
+	}
+
+	err = utils.WritePlayHistoryJSON(file, export)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return failure("Unable to write %s: %v", *output, err)
+	}
+
+	fmt.Fprintf(stdout, "Wrote play history to %s\n", *output)
+	return ExitCodeSuccess
+}
+
+func runHistoryImport(args []string) int {
+	if len(args) != 1 {
+		return usageError(historyUsage)
+	}
+
+	if !utils.IsPlayHistoryImportFile(args[0]) {
+		return failure("%s is not a play history JSON or sessions CSV export", args[0])
+	}
+
+	export, err := utils.ReadPlayHistoryExport(args[0])
+	if err != nil {
+		return failure("Unable to read %s: %v", args[0], err)
+	}
+
+	result, err := utils.ImportPlayHistory(export)
+	if err != nil {
+		return failure("Unable to import play history: %v", err)
+	}
+
+	fmt.Fprintf(stdout, "Imported %d sessions (%d already present) across %d new and %d existing games\n",
+		result.SessionsAdded, result.SessionsSkipped, result.GamesAdded, result.GamesMerged)
+	return ExitCodeSuccess
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"strings"
+)
+
+func runRename(args []string) int {
+	if len(args) != 2 {
+		return usageError("rename <rom-path> <new-name>")
+	}
+
+	game, romDirectory, err := utils.ResolveRomPath(args[0])
+	if err != nil {
+		return failure("%v", err)
+	}
+
+	newName := strings.TrimSpace(args[1])
+	if newName == "" || strings.Contains(newName, "/") {
+		return failure("Invalid new name: %q", args[1])
+	}
+
+	newFilename, err := utils.RenameRom(game, newName, romDirectory, state.GetCollectionMap())
+	state.ClearCollectionMap()
+	if err != nil {
+		return failure("Unable to rename %s: %v", game.Filename, err)
+	}
+
+	fmt.Fprintf(stdout, "Renamed %s to %s\n", game.Filename, newFilename)
+	return ExitCodeSuccess
+}
+
+func runArchive(args []string) int {
+	flags := flag.NewFlagSet("archive", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	archiveName := flags.String("archive", ".Archive", "archive folder to move the ROMs into")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() == 0 {
+		return usageError("archive [-archive name] <rom-path>...")
+	}
+
+	archive := utils.PrepArchiveName(*archiveName)
+	exitCode := ExitCodeSuccess
+
+	collectionMap := state.GetCollectionMap()
+	defer state.ClearCollectionMap()
+
+	for _, romPath := range flags.Args() {
+		game, romDirectory, err := utils.ResolveRomPath(romPath)
+		if err != nil {
+			exitCode = failure("%v", err)
+			continue
+		}
+
+		if err := utils.ArchiveRom(game, romDirectory, archive, collectionMap); err != nil {
+			exitCode = failure("Unable to archive %s: %v", game.Filename, err)
+			continue
+		}
+
+		fmt.Fprintf(stdout, "Archived %s into %s\n", game.Filename, archive)
+	}
+
+	return exitCode
+}
+
+func runRestore(args []string) int {
+	if len(args) == 0 {
+		return usageError("restore <archived-rom-path>...")
+	}
+
+	exitCode := ExitCodeSuccess
+
+	collectionMap := state.GetCollectionMap()
+	defer state.ClearCollectionMap()
+
+	for _, romPath := range args {
+		game, romDirectory, err := utils.ResolveRomPath(romPath)
+		if err != nil {
+			exitCode = failure("%v", err)
+			continue
+		}
+
+		archive, err := utils.ArchiveFromPath(romDirectory.Path)
+		if err != nil {
+			exitCode = failure("%v", err)
+			continue
+		}
+
+		if err := utils.RestoreRom(game, romDirectory, archive, collectionMap); err != nil {
+			exitCode = failure("Unable to restore %s: %v", game.Filename, err)
+			continue
+		}
+
+		fmt.Fprintf(stdout, "Restored %s from %s\n", game.Filename, archive.DisplayName)
+	}
+
+	return exitCode
+}
@@ -12,6 +12,7 @@ require (
 	github.com/veandco/go-sdl2 v0.4.40
 	go.uber.org/atomic v1.11.0
 	go.uber.org/zap v1.27.0
+	golang.org/x/image v0.30.0
 	gopkg.in/yaml.v3 v3.0.1
 	qlova.tech v0.1.1
 )
@@ -33,7 +34,6 @@ require (
 	github.com/spf13/pflag v1.0.7 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	golang.org/x/image v0.30.0 // indirect
 	golang.org/x/net v0.43.0 // indirect
 	golang.org/x/sys v0.35.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
@@ -15,17 +15,32 @@ type Action struct {
 	ArchiveDelete,
 	DeleteRom,
 	Nuke,
+	OneGameOneRom,
+	BacklogStatus,
+	ManageSaves,
+	SaveStates,
 
 	CollectionRename,
-	CollectionAlphabetize,
+	CollectionSort,
 	CollectionDelete,
 	CollectionAdd,
+	CollectionExport,
+	CollectionDuplicate,
+	CollectionMerge,
+	CollectionIntersect,
+	CollectionSubtract,
+	CollectionSplit,
 
 	PlayHistoryOpen,
 	PlayHistoryAdopt,
+	PlayHistoryDelete,
 
 	GlobalDownloadArt,
-	GlobalClearRecents sum.Int[Action]
+	GlobalClearRecents,
+	GlobalFindDuplicates,
+	GlobalOneGameOneRom,
+	GlobalBackupSaves,
+	GlobalRestoreSaves sum.Int[Action]
 }
 
 var Actions = sum.Int[Action]{}.Sum()
@@ -40,24 +55,43 @@ var ActionMap = map[string]sum.Int[Action]{
 	"Delete Archive":     Actions.ArchiveDelete,
 	"Delete ROM":         Actions.DeleteRom,
 	"Nuclear Option":     Actions.Nuke,
+	"1 Game 1 ROM":       Actions.OneGameOneRom,
+	"Backlog Status":     Actions.BacklogStatus,
+	"Manage Saves":       Actions.ManageSaves,
+	"Save States":        Actions.SaveStates,
+	"Clear Save States":  Actions.ClearSaveStates,
 
 	"Rename Collection": Actions.CollectionRename,
-	"Alphabetize Games": Actions.CollectionAlphabetize,
+	"Sort Games":        Actions.CollectionSort,
 	"Delete Collection": Actions.CollectionDelete,
 	"Add to Collection": Actions.CollectionAdd,
+	"Export Collection": Actions.CollectionExport,
 
-	"View Play Details": Actions.PlayHistoryOpen,
+	"Duplicate Collection":      Actions.CollectionDuplicate,
+	"Merge With Collection":     Actions.CollectionMerge,
+	"Intersect With Collection": Actions.CollectionIntersect,
+	"Subtract Collection":       Actions.CollectionSubtract,
+	"Split by Platform":         Actions.CollectionSplit,
+
+	"View Play Details":       Actions.PlayHistoryOpen,
+	"Rehome Orphaned History": Actions.PlayHistoryAdopt,
+	"Delete Existing History": Actions.PlayHistoryDelete,
 }
 
 var GlobalActionMap = map[string]sum.Int[Action]{
 	"Download Missing Art":  Actions.GlobalDownloadArt,
 	"Clear Recently Played": Actions.GlobalClearRecents,
+	"Find Duplicates":       Actions.GlobalFindDuplicates,
+	"1 Game 1 ROM":          Actions.GlobalOneGameOneRom,
+	"Backup Saves":          Actions.GlobalBackupSaves,
+	"Restore Saves":         Actions.GlobalRestoreSaves,
 }
 
 var ActionKeys = []string{
 	"Rename ROM",
 	"Add to Collection",
-	//"Clear Save States",
+	"Backlog Status",
+	"Manage Saves",
 	"Archive ROM",
 	"Delete ROM",
 	//"Nuclear Option",
@@ -66,6 +100,10 @@ var ActionKeys = []string{
 var GlobalActionKeys = []string{
 	"Download Missing Art",
 	"Clear Recently Played",
+	"Find Duplicates",
+	"1 Game 1 ROM",
+	"Backup Saves",
+	"Restore Saves",
 }
 
 var BulkActionKeys = []string{
@@ -74,13 +112,20 @@ var BulkActionKeys = []string{
 	"Delete Art",
 	//"Clear Game Tracker",
 	"Archive ROM",
+	"1 Game 1 ROM",
 	//"Delete ROM",
 	//"Nuclear Option",
 }
 
 var CollectionActionKeys = []string{
-	"Alphabetize Games",
+	"Sort Games",
 	"Rename Collection",
+	"Duplicate Collection",
+	"Merge With Collection",
+	"Intersect With Collection",
+	"Subtract Collection",
+	"Split by Platform",
+	"Export Collection",
 	"Delete Collection",
 }
 
@@ -90,8 +135,8 @@ var ArchiveActionKeys = []string{
 }
 
 var PlayHistoryActionKeys = []string{
-	//"Rehome Orphaned History",
-	//"Delete Existing History",
+	"Rehome Orphaned History",
+	"Delete Existing History",
 }
 
 var ActionNames = map[sum.Int[Action]]string{}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	BacklogStatusBacklog   = "Backlog"
+	BacklogStatusPlaying   = "Playing"
+	BacklogStatusCompleted = "Completed"
+	BacklogStatusAbandoned = "Abandoned"
+)
+
+// BacklogStatuses is the order statuses are offered and listed in.
+var BacklogStatuses = []string{
+	BacklogStatusPlaying,
+	BacklogStatusBacklog,
+	BacklogStatusCompleted,
+	BacklogStatusAbandoned,
+}
+
+type Backlog struct {
+	Games []BacklogEntry `yaml:"games"`
+}
+
+// BacklogEntry is keyed by the ROM path relative to the Roms folder, the same form the game tracker uses.
+type BacklogEntry struct {
+	Path        string    `yaml:"path"`
+	Name        string    `yaml:"name"`
+	Status      string    `yaml:"status"`
+	TargetHours float64   `yaml:"target_hours,omitempty"`
+	UpdatedAt   time.Time `yaml:"updated_at"`
+}
+
+// BacklogProgress pairs an entry with the play time the game tracker has for it.
+// RomPath points into the archive for archived games, Missing is set once the ROM is gone.
+type BacklogProgress struct {
+	Entry    BacklogEntry
+	RomPath  string
+	PlayTime int
+	Missing  bool
+}
+
+// Percent of the target hours played, zero without a target.
+func (bp BacklogProgress) Percent() int {
+	if bp.Entry.TargetHours <= 0 {
+		return 0
+	}
+	return int(float64(bp.PlayTime) / (bp.Entry.TargetHours * 3600) * 100)
+}
@@ -11,3 +11,21 @@ type Collection struct {
 func (c Collection) Value() interface{} {
 	return c
 }
+
+const (
+	CollectionEntryMoved    = "Moved"
+	CollectionEntryRenamed  = "Renamed"
+	CollectionEntryArchived = "Archived"
+)
+
+// MissingCollectionEntry is a collection line that no longer points at a file, along with the best replacement found.
+type MissingCollectionEntry struct {
+	Collection   Collection
+	Entry        string
+	ResolvedPath string
+	Resolution   string
+}
+
+func (m MissingCollectionEntry) Resolvable() bool {
+	return m.ResolvedPath != ""
+}
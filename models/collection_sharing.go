@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// CollectionExport describes a collection without device paths so it can be imported against another library.
+type CollectionExport struct {
+	Name       string                 `json:"name"`
+	ExportedAt time.Time              `json:"exported_at"`
+	Games      []CollectionExportGame `json:"games"`
+}
+
+// CollectionExportGame is matched by platform tag and title, the hash and region only break ties.
+type CollectionExportGame struct {
+	Title    string `json:"title"`
+	Platform string `json:"platform"`
+	Filename string `json:"filename,omitempty"`
+	Region   string `json:"region,omitempty"`
+	SHA1     string `json:"sha1,omitempty"`
+}
+
+type CollectionImportResult struct {
+	Collection Collection
+	Unresolved []CollectionExportGame
+}
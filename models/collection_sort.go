@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	CollectionSortAlphabetical = "Alphabetical"
+	CollectionSortPlatform     = "Platform"
+	CollectionSortReleaseYear  = "Release Year"
+	CollectionSortPlayTime     = "Play Time"
+	CollectionSortLastPlayed   = "Last Played"
+	CollectionSortDateAdded    = "Date Added"
+)
+
+// CollectionSortModes is the order sort modes are offered in.
+var CollectionSortModes = []string{
+	CollectionSortAlphabetical,
+	CollectionSortPlatform,
+	CollectionSortReleaseYear,
+	CollectionSortPlayTime,
+	CollectionSortLastPlayed,
+	CollectionSortDateAdded,
+}
+
+// CollectionSort remembers how a collection is ordered and when each entry was added through Game Manager.
+type CollectionSort struct {
+	Mode  string               `json:"mode,omitempty"`
+	Added map[string]time.Time `json:"added,omitempty"`
+}
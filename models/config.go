@@ -14,6 +14,13 @@ type Config struct {
 	LogLevel        			string                   		`yaml:"log_level"`
 	PlayHistoryShowCollections	bool                            `yaml:"play_history_show_collections"`
 	PlayHistoryShowArchives     bool                          	`yaml:"play_history_show_archives"`
+	RegionPriority              []string                        `yaml:"region_priority"`
+	BacklogCompletedCollection  string                          `yaml:"backlog_completed_collection"`
+	AutoCollections             bool                            `yaml:"auto_collections"`
+	SaveBackupDirectory         string                          `yaml:"save_backup_directory"`
+	SaveBackupKeep              int                             `yaml:"save_backup_keep"`
+	SaveBackupIntervalDays      int                             `yaml:"save_backup_interval_days"`
+	TrashRetentionDays          int                             `yaml:"trash_retention_days"`
 }
 
 func (c *Config) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -0,0 +1,28 @@
+package models
+
+const (
+	DuplicateIdentical = "Identical"
+	DuplicateSameTitle = "Same Title"
+)
+
+type DuplicateRom struct {
+	Path        string
+	Size        int64
+	PlayTime    int
+	Collections []string
+	Archived    bool
+}
+
+// DuplicateGroup is a set of ROMs that share either a content hash or a normalized title on the same platform.
+type DuplicateGroup struct {
+	Title  string
+	Reason string
+	Roms   []DuplicateRom
+}
+
+// OneGameOneRomProposal keeps the preferred variant of a title and lists the variants to archive.
+type OneGameOneRomProposal struct {
+	Title   string
+	Keep    string
+	Archive []string
+}
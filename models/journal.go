@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	JournalEntryMove          = "move"
+	JournalEntryTrackerPath   = "tracker_path"
+	JournalEntryTrackerDelete = "tracker_delete"
+	JournalEntryFileSnapshot  = "file_snapshot"
+	JournalEntryTrackerMerge  = "tracker_merge"
+	JournalEntryCopy          = "copy"
+
+	// JournalEntryCollection is what file snapshots were called before they covered more than collections
+	JournalEntryCollection = "collection"
+)
+
+type JournalEntry struct {
+	Type        string           `json:"type"`
+	Source      string           `json:"source,omitempty"`
+	Destination string           `json:"destination,omitempty"`
+	OldName     string           `json:"old_name,omitempty"`
+	NewName     string           `json:"new_name,omitempty"`
+	Contents    string           `json:"contents,omitempty"`
+	Created     bool             `json:"created,omitempty"`
+	TrackerRows *TrackerSnapshot `json:"tracker_rows,omitempty"`
+}
+
+type TrackerSnapshot struct {
+	Rom          map[string]interface{}   `json:"rom"`
+	PlayActivity []map[string]interface{} `json:"play_activity"`
+}
+
+type JournalBatch struct {
+	Id          string         `json:"id"`
+	Description string         `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Undone      bool           `json:"undone"`
+	Entries     []JournalEntry `json:"entries"`
+}
@@ -0,0 +1,14 @@
+package models
+
+const (
+	OrphanedFileKindArt  = "Art"
+	OrphanedFileKindSave = "Save"
+)
+
+// OrphanedFile is art or a save file left behind by a ROM that is no longer in the library or any archive.
+type OrphanedFile struct {
+	Kind     string
+	Platform string
+	Path     string
+	Size     int64
+}
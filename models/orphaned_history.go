@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// OrphanedHistory is a game tracker rom row whose file no longer exists in the ROM directory or an archive.
+type OrphanedHistory struct {
+	RomId       int
+	Name        string
+	TrackerPath string
+	PlayTime    int
+	PlayCount   int
+	LastPlayed  time.Time
+}
+
+type RehomeCandidate struct {
+	Path        string
+	TrackerPath string
+	Score       float64
+	HasHistory  bool
+}
@@ -18,9 +18,17 @@ type PlayHistoryGranular struct {
 	UpdateTime	int
 }
 
+// PlayHistoryQuery narrows game tracker queries. Empty fields don't filter, Start is inclusive and End exclusive.
+type PlayHistoryQuery struct {
+	RomIds		[]int
+	Consoles	[]string
+	Start		time.Time
+	End			time.Time
+}
+
 type PlayHistorySearchFilter struct {
 	DisplayName	string
-	SqlFilter	string
+	Query		PlayHistoryQuery
 	FilterType	int
 	PlayTime	int
 }
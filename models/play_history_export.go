@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// PlayHistoryExport is a portable copy of the game tracker, one entry per rom row with its play sessions.
+type PlayHistoryExport struct {
+	ExportedAt    time.Time               `json:"exported_at"`
+	TotalPlayTime int                     `json:"total_play_time"`
+	Games         []PlayHistoryExportGame `json:"games"`
+}
+
+type PlayHistoryExportGame struct {
+	Name           string                     `json:"name"`
+	FilePath       string                     `json:"file_path"`
+	Console        string                     `json:"console"`
+	PlayTimeTotal  int                        `json:"play_time_total"`
+	PlayCountTotal int                        `json:"play_count_total"`
+	FirstPlayedAt  int64                      `json:"first_played_at"`
+	LastPlayedAt   int64                      `json:"last_played_at"`
+	Rom            map[string]interface{}     `json:"rom,omitempty"`
+	Sessions       []PlayHistoryExportSession `json:"sessions"`
+}
+
+type PlayHistoryExportSession struct {
+	PlayTime  int   `json:"play_time"`
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+type PlayHistoryImportResult struct {
+	GamesAdded      int
+	GamesMerged     int
+	SessionsAdded   int
+	SessionsSkipped int
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// PlayHistoryStatistics is everything the statistics chart shows, computed from play_activity in local time.
+type PlayHistoryStatistics struct {
+	TotalPlayTime int
+	SessionCount  int
+	Streaks       PlayStreaks
+	Heatmap       [7][24]int
+	MonthlyTop    []MonthlyTopGame
+	Abandoned     []AbandonedGame
+}
+
+// PlayStreaks counts consecutive days with at least one session. The current streak
+// stays alive until a full day passes without play.
+type PlayStreaks struct {
+	Current      int
+	Longest      int
+	LongestStart time.Time
+	LongestEnd   time.Time
+}
+
+type MonthlyTopGame struct {
+	Month    time.Time
+	Name     string
+	PlayTime int
+}
+
+type AbandonedGame struct {
+	Name       string
+	Console    string
+	PlayTime   int
+	PlayCount  int
+	LastPlayed time.Time
+}
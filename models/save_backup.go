@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// SaveBackup is one zip of the whole Saves folder and every save state.
+type SaveBackup struct {
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// SaveBackupGame groups the files of a backup that belong to one game so they can be restored on their own.
+type SaveBackupGame struct {
+	Tag   string
+	Name  string
+	Files []string
+}
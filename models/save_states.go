@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// SaveStateAutoResumeSlot is the slot NextUI writes when a game is closed and resumes from on the next launch.
+const SaveStateAutoResumeSlot = 9
+
+type SaveState struct {
+	Slot        int
+	Path        string
+	PreviewPath string
+	Size        int64
+	ModTime     time.Time
+}
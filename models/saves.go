@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type SaveFile struct {
+	Path     string
+	Filename string
+	Size     int64
+	ModTime  time.Time
+}
+
+// SaveSnapshot is one timestamped backup of a game's save files.
+type SaveSnapshot struct {
+	Path      string
+	CreatedAt time.Time
+	Files     []SaveFile
+}
@@ -33,6 +33,30 @@ type ScreenName struct {
 	PlayHistoryGameList,
 	PlayHistoryList,
 	PlayHistoryFilter,
+	PlayHistoryStatistics,
+	YearInReview,
+	YearInReviewReport,
+	Backlog,
+	BacklogStatus,
+	SmartCollections,
+	OrphanedHistory,
+	RehomeCandidates,
+
+	OperationHistory,
+	ValidateCollections,
+	VerifyRoms,
+	VerificationReport,
+	DuplicateGroups,
+	DuplicateGroupDetails,
+	OneGameOneRom,
+	StorageUsage,
+	StorageDetails,
+	OrphanedFiles,
+	ImportPlayHistory,
+	ImportCollection,
+	ManageSaves,
+	SaveStates,
+	SaveBackups,
 
 	GlobalActions sum.Int[ScreenName]
 }
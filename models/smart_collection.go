@@ -0,0 +1,27 @@
+package models
+
+type SmartCollectionFile struct {
+	Collections []SmartCollection `yaml:"collections"`
+}
+
+// SmartCollection is a rule set that gets materialized into a regular NextUI collection file named after it.
+type SmartCollection struct {
+	Name            string               `yaml:"name"`
+	RefreshOnLaunch bool                 `yaml:"refresh_on_launch"`
+	Rules           SmartCollectionRules `yaml:"rules"`
+}
+
+// SmartCollectionRules must all match for a ROM to be included, unset rules match everything.
+// Dates are either YYYY-MM-DD or relative to today, e.g. 30d, 4w, 6m, 1y, this_month or this_year.
+type SmartCollectionRules struct {
+	Platforms     []string `yaml:"platforms,omitempty"`
+	Keywords      []string `yaml:"keywords,omitempty"`
+	Regions       []string `yaml:"regions,omitempty"`
+	MinPlayHours  *float64 `yaml:"min_play_hours,omitempty"`
+	MaxPlayHours  *float64 `yaml:"max_play_hours,omitempty"`
+	PlayedAfter   string   `yaml:"played_after,omitempty"`
+	PlayedBefore  string   `yaml:"played_before,omitempty"`
+	NeverPlayed   bool     `yaml:"never_played,omitempty"`
+	HasArt        *bool    `yaml:"has_art,omitempty"`
+	BacklogStatus []string `yaml:"backlog_status,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	StorageKindPlatform = "Platform"
+	StorageKindArchive  = "Archive"
+	StorageKindMedia    = "Media"
+	StorageKindSaves    = "Saves"
+)
+
+type StorageItem struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// StorageUsage is the footprint of one platform, archive, media or save folder.
+type StorageUsage struct {
+	Name      string        `json:"name"`
+	Kind      string        `json:"kind"`
+	Path      string        `json:"path"`
+	Size      int64         `json:"size"`
+	FileCount int           `json:"file_count"`
+	Largest   []StorageItem `json:"largest"`
+}
+
+type StorageReport struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Total       int64          `json:"total"`
+	Entries     []StorageUsage `json:"entries"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+
+const (
+	VerificationVerified = "Verified"
+	VerificationBadDump  = "Bad Dump"
+	VerificationUnknown  = "Unknown"
+	VerificationMisnamed = "Misnamed"
+)
+
+type RomHashes struct {
+	Size  int64  `json:"size"`
+	CRC32 string `json:"crc32"`
+	MD5   string `json:"md5"`
+	SHA1  string `json:"sha1"`
+}
+
+// DatEntry is a single <rom> from a Logiqx XML DAT along with the game it belongs to.
+type DatEntry struct {
+	GameName string
+	RomName  string
+	Size     int64
+	CRC32    string
+	MD5      string
+	SHA1     string
+	Status   string
+	Year     string
+}
+
+type VerificationResult struct {
+	Game          shared.Item
+	Status        string
+	CanonicalName string
+}
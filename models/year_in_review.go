@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type YearInReview struct {
+	Year           int
+	TotalPlayTime  int
+	SessionCount   int
+	GamesPlayed    int
+	TopGames       []YearInReviewGame
+	TopConsoles    []YearInReviewConsole
+	LongestSession YearInReviewSession
+	FirstSession   YearInReviewSession
+	LastSession    YearInReviewSession
+	NewGames       []YearInReviewGame
+}
+
+// YearInReviewGame play time and count only cover the reviewed year.
+type YearInReviewGame struct {
+	Name        string
+	Console     string
+	Path        string
+	ArtPath     string
+	PlayTime    int
+	PlayCount   int
+	FirstPlayed time.Time
+}
+
+type YearInReviewConsole struct {
+	Name     string
+	PlayTime int
+}
+
+type YearInReviewSession struct {
+	Name      string
+	Console   string
+	PlayTime  int
+	StartedAt time.Time
+}
@@ -2,7 +2,9 @@ package state
 
 import (
 	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	"go.uber.org/atomic"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 	"nextui-game-manager/models"
 	"nextui-game-manager/utils"
@@ -124,7 +126,11 @@ func GetPlayMaps() (map[string][]models.PlayHistoryAggregate, map[string]int, in
 
 func updatePlayMaps() {
 	temp := GetAppState()
-	temp.GamePlayMap, temp.ConsolePlayMap, temp.TotalPlay = utils.GenerateCurrentGameStats("")
+	gamePlayMap, consolePlayMap, totalPlay, err := utils.GenerateCurrentGameStats(models.PlayHistoryQuery{})
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to load play history", zap.Error(err))
+	}
+	temp.GamePlayMap, temp.ConsolePlayMap, temp.TotalPlay = gamePlayMap, consolePlayMap, totalPlay
 	UpdateAppState(temp)
 }
 
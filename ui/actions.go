@@ -9,6 +9,7 @@ import (
 	"nextui-game-manager/state"
 	"nextui-game-manager/utils"
 	"qlova.tech/sum"
+	"slices"
 )
 
 type ActionsScreen struct {
@@ -49,6 +50,15 @@ func (a ActionsScreen) Draw() (action interface{}, exitCode int, e error) {
 		actions = utils.InsertIntoSlice(actions, 1, "Delete Art")
 	}
 
+	saveStates, err := utils.FindSaveStates(a.Game, a.RomDirectory)
+	if err != nil {
+		logger.Error("failed to find save states", zap.Error(err))
+	}
+
+	if len(saveStates) > 0 {
+		actions = utils.InsertIntoSlice(actions, slices.Index(actions, "Manage Saves")+1, "Save States", "Clear Save States")
+	}
+
 	gamePlayMap, _, _ := state.GetPlayMaps()
 	gameAggregate, _ := utils.CollectGameAggregateFromGame(a.Game, gamePlayMap)
 	if gameAggregate.PlayCountTotal != 0 {
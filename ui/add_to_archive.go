@@ -91,8 +91,14 @@ func (atas AddToArchiveScreen) Draw() (item interface{}, exitCode int, e error)
 			return nil, 404, nil
 		}
 
+		utils.BeginOperation(fmt.Sprintf("Archive %d Games into %s", len(atas.Games), archiveFolder))
+		defer utils.CommitOperation()
+
+		collectionMap := state.GetCollectionMap()
+		defer state.ClearCollectionMap()
+
 		for _, game := range atas.Games {
-			if err := utils.ArchiveRom(game, atas.RomDirectory, archiveFolder); err != nil {
+			if err := utils.ArchiveRom(game, atas.RomDirectory, archiveFolder, collectionMap); err != nil {
 				utils.ShowTimedMessage(fmt.Sprintf("Unable to archive %s!", game.DisplayName), time.Second*3)
 				return nil, 404, err
 			}
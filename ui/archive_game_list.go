@@ -161,9 +161,15 @@ func (agl ArchiveGamesListScreen) Draw() (item interface{}, exitCode int, e erro
 			return agl.SearchFilter, 4, nil
 		}
 
+		utils.BeginOperation(fmt.Sprintf("Restore %d Games from %s", len(rawSelection), agl.Archive.DisplayName))
+		defer utils.CommitOperation()
+
+		collectionMap := state.GetCollectionMap()
+		defer state.ClearCollectionMap()
+
 		for _, selection := range rawSelection {
 			item := selection.Metadata.(shared.Item)
-			err := utils.RestoreRom(item, agl.RomDirectory, agl.Archive)
+			err := utils.RestoreRom(item, agl.RomDirectory, agl.Archive, collectionMap)
 			if err != nil {
 				utils.ShowTimedMessage(fmt.Sprintf("Unable to restore %s!", item.DisplayName), time.Second*2)
 				return shared.RomDirectory{}, 0, err
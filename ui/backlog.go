@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"slices"
+	"time"
+)
+
+var backlogTargetHours = []float64{0, 5, 10, 15, 20, 30, 40, 50, 75, 100}
+
+type BacklogScreen struct{}
+
+func InitBacklogScreen() BacklogScreen {
+	return BacklogScreen{}
+}
+
+func (bs BacklogScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.Backlog
+}
+
+// Lists backlog games by status with their progress towards the target hours
+func (bs BacklogScreen) Draw() (item interface{}, exitCode int, e error) {
+	gamePlayMap, _, _ := state.GetPlayMaps()
+	progress, err := utils.GetBacklogProgress(gamePlayMap)
+	if err != nil {
+		utils.ShowTimedMessage("Unable to load backlog!", time.Second*2)
+		return nil, -1, err
+	}
+
+	remaining := 0
+	var menuItems []gaba.MenuItem
+	for _, game := range progress {
+		if game.Entry.Status == models.BacklogStatusBacklog || game.Entry.Status == models.BacklogStatusPlaying {
+			remaining++
+		}
+
+		played := fmt.Sprintf("%.1fH", float64(game.PlayTime)/3600.0)
+		if game.Entry.TargetHours > 0 {
+			played = fmt.Sprintf("%d%% of %.0fH", game.Percent(), game.Entry.TargetHours)
+		}
+
+		// same markers as the play history list: archived games and games whose ROM is gone
+		home := ""
+		if game.Missing {
+			home = "(-) "
+		} else if archive, err := utils.ArchiveFromPath(game.RomPath); err == nil {
+			home = "(" + string(utils.CleanArchiveName(archive.DisplayName)[0]) + ") "
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("[%s] %s : %s%s", game.Entry.Status, played, home, game.Entry.Name),
+			Selected: false,
+			Focused:  false,
+			Metadata: game,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("Backlog: %d To Go", remaining), menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.EmptyMessage = "Set a Backlog Status from a game's Actions"
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Update"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return utils.BacklogGameItem(selection.Unwrap().SelectedItem.Metadata.(models.BacklogProgress)), 0, nil
+	}
+
+	return nil, 2, nil
+}
+
+type BacklogStatusScreen struct {
+	Game          shared.Item
+	ActionsScreen *ActionsScreen
+}
+
+// InitBacklogStatusScreen edits the status of a game, returning to actionsScreen when opened from it or to the Backlog otherwise.
+func InitBacklogStatusScreen(game shared.Item, actionsScreen *ActionsScreen) BacklogStatusScreen {
+	return BacklogStatusScreen{
+		Game:          game,
+		ActionsScreen: actionsScreen,
+	}
+}
+
+func (bss BacklogStatusScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.BacklogStatus
+}
+
+func (bss BacklogStatusScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+
+	entry, _ := utils.FindBacklogEntry(bss.Game)
+
+	statusOptions := []gaba.Option{{DisplayName: "Not Tracked", Value: ""}}
+	selectedStatus := 0
+	for i, status := range models.BacklogStatuses {
+		statusOptions = append(statusOptions, gaba.Option{DisplayName: status, Value: status})
+		if status == entry.Status {
+			selectedStatus = i + 1
+		}
+	}
+
+	targets := backlogTargetHours
+	if entry.TargetHours > 0 && !slices.Contains(targets, entry.TargetHours) {
+		targets = append(append([]float64{}, targets...), entry.TargetHours)
+	}
+
+	var targetOptions []gaba.Option
+	selectedTarget := 0
+	for i, target := range targets {
+		displayName := "None"
+		if target > 0 {
+			displayName = fmt.Sprintf("%g Hours", target)
+		}
+		targetOptions = append(targetOptions, gaba.Option{DisplayName: displayName, Value: target})
+		if target == entry.TargetHours {
+			selectedTarget = i
+		}
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item:           gaba.MenuItem{Text: "Status"},
+			Options:        statusOptions,
+			SelectedOption: selectedStatus,
+		},
+		{
+			Item:           gaba.MenuItem{Text: "Target Hours"},
+			Options:        targetOptions,
+			SelectedOption: selectedTarget,
+		},
+	}
+
+	result, err := gaba.OptionsList(bss.Game.DisplayName, items, []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "←→", HelpText: "Cycle"},
+		{ButtonName: "Start", HelpText: "Save"},
+	})
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if result.IsNone() {
+		return nil, 2, nil
+	}
+
+	status := ""
+	targetHours := 0.0
+	for _, option := range result.Unwrap().Items {
+		switch option.Item.Text {
+		case "Status":
+			status = option.Options[option.SelectedOption].Value.(string)
+		case "Target Hours":
+			targetHours = option.Options[option.SelectedOption].Value.(float64)
+		}
+	}
+
+	utils.BeginOperation(fmt.Sprintf("Set backlog status of %s", bss.Game.DisplayName))
+	defer utils.CommitOperation()
+
+	if err := utils.SetBacklogStatus(bss.Game, status, targetHours); err != nil {
+		logger.Error("Unable to update backlog", zap.Error(err))
+		utils.ShowTimedMessage("Unable to update backlog!", time.Second*2)
+		return nil, -1, err
+	}
+
+	completedCollection := state.GetAppState().Config.BacklogCompletedCollection
+	if status == models.BacklogStatusCompleted && entry.Status != models.BacklogStatusCompleted && completedCollection != "" {
+		err := utils.AddToCompletedCollection(bss.Game, completedCollection, state.GetCollectionMap())
+		state.ClearCollectionMap()
+		if err != nil {
+			logger.Error("Unable to add completed game to collection", zap.Error(err))
+			utils.ShowTimedMessage(fmt.Sprintf("Unable to add to %s!", completedCollection), time.Second*2)
+		} else {
+			utils.ShowTimedMessage(fmt.Sprintf("Completed! Added to %s", completedCollection), time.Second*2)
+		}
+	}
+
+	return status, 0, nil
+}
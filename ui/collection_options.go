@@ -8,7 +8,11 @@ import (
 	"nextui-game-manager/models"
 	"nextui-game-manager/state"
 	"nextui-game-manager/utils"
+	"path/filepath"
 	"qlova.tech/sum"
+	"slices"
+	"strings"
+	"time"
 )
 
 type CollectionOptionsScreen struct {
@@ -94,11 +98,96 @@ func (c CollectionOptionsScreen) Draw() (screenReturn interface{}, exitCode int,
 				return nil, 0, nil
 			}
 
-		case models.Actions.CollectionAlphabetize:
-			confirm := utils.ConfirmAction(fmt.Sprintf("Alphabetize %s?\nThis cannot be undone!", c.Collection.DisplayName))
+		case models.Actions.CollectionDuplicate:
+			name, err := promptCollectionName(fmt.Sprintf("%s Copy", c.Collection.DisplayName))
+			if err != nil || name == "" {
+				return c.Collection, 2, err
+			}
+
+			created, err := utils.DuplicateCollection(state.GetCollectionMap(), c.Collection, name)
+			return reportDerivedCollection(created, err)
+
+		case models.Actions.CollectionMerge, models.Actions.CollectionIntersect, models.Actions.CollectionSubtract:
+			other, err := chooseOtherCollection(c.Collection, action)
+			if err != nil || other.CollectionFile == "" {
+				return c.Collection, 2, err
+			}
+
+			operator := map[sum.Int[models.Action]]string{
+				models.Actions.CollectionMerge:     "+",
+				models.Actions.CollectionIntersect: "&",
+				models.Actions.CollectionSubtract:  "-",
+			}[action]
+
+			name, err := promptCollectionName(fmt.Sprintf("%s %s %s", c.Collection.DisplayName, operator, other.DisplayName))
+			if err != nil || name == "" {
+				return c.Collection, 2, err
+			}
+
+			var created models.Collection
+			switch action {
+			case models.Actions.CollectionMerge:
+				created, err = utils.MergeCollections(state.GetCollectionMap(), c.Collection, other, name)
+			case models.Actions.CollectionIntersect:
+				created, err = utils.IntersectCollections(state.GetCollectionMap(), c.Collection, other, name)
+			default:
+				created, err = utils.SubtractCollections(state.GetCollectionMap(), c.Collection, other, name)
+			}
+			return reportDerivedCollection(created, err)
+
+		case models.Actions.CollectionSplit:
+			if !utils.ConfirmAction(fmt.Sprintf("Split %s into a collection per platform?", c.Collection.DisplayName)) {
+				return c.Collection, 2, nil
+			}
+
+			created, err := utils.SplitCollectionByPlatform(state.GetCollectionMap(), c.Collection)
+			state.ClearCollectionMap()
+			if err != nil {
+				logger.Error("failed to split collection", zap.Error(err))
+				utils.ShowTimedMessage("Unable to split collection!", time.Second*2)
+				return nil, -1, err
+			}
+
+			utils.ShowTimedMessage(fmt.Sprintf("Split into %d collections", len(created)), time.Second*2)
+			return nil, 0, nil
+
+		case models.Actions.CollectionExport:
+			var path string
+			gabagool.ProcessMessage(fmt.Sprintf("Exporting %s...", c.Collection.DisplayName), gabagool.ProcessMessageOptions{}, func() (interface{}, error) {
+				var export models.CollectionExport
+				export, err = utils.ExportCollection(c.Collection)
+				if err == nil {
+					path, err = utils.SaveCollectionExport(export, utils.GetExportDirectory())
+				}
+				return nil, nil
+			})
+
+			if err != nil {
+				logger.Error("failed to export collection", zap.Error(err))
+				utils.ShowTimedMessage("Unable to export collection!", time.Second*2)
+				return nil, -1, err
+			}
+
+			utils.ShowTimedMessage(fmt.Sprintf("Exported to %s", path), time.Second*3)
 
-			if confirm {
-				utils.AlphabetizeCollection(c.Collection)
+		case models.Actions.CollectionSort:
+			mode, err := chooseSortMode(c.Collection)
+			if err != nil || mode == "" {
+				return c.Collection, 2, err
+			}
+
+			gabagool.ProcessMessage(fmt.Sprintf("Sorting %s...", c.Collection.DisplayName), gabagool.ProcessMessageOptions{}, func() (interface{}, error) {
+				utils.BeginOperation(fmt.Sprintf("Sort %s by %s", c.Collection.DisplayName, mode))
+				defer utils.CommitOperation()
+
+				_, err = utils.SortCollection(c.Collection, mode)
+				return nil, nil
+			})
+
+			if err != nil {
+				logger.Error("failed to sort collection", zap.Error(err))
+				utils.ShowTimedMessage("Unable to sort collection!", time.Second*2)
+				return nil, -1, err
 			}
 		}
 
@@ -107,3 +196,123 @@ func (c CollectionOptionsScreen) Draw() (screenReturn interface{}, exitCode int,
 
 	return c.Collection, 2, nil
 }
+
+// chooseSortMode lists the sort modes starting on the collection's current one, an empty mode means the user backed out
+func chooseSortMode(collection models.Collection) (string, error) {
+	current := utils.GetCollectionSortMode(collection)
+
+	var menuItems []gabagool.MenuItem
+	for _, mode := range models.CollectionSortModes {
+		text := mode
+		if mode == current {
+			text = mode + " (Current)"
+		}
+
+		menuItems = append(menuItems, gabagool.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: mode,
+		})
+	}
+
+	options := gabagool.DefaultListOptions(fmt.Sprintf("Sort %s", collection.DisplayName), menuItems)
+	options.SmallTitle = true
+	options.SelectedIndex = max(slices.Index(models.CollectionSortModes, current), 0)
+	options.EnableHelp = true
+	options.HelpTitle = "Sort Games"
+	options.HelpText = []string{
+		"The chosen order is remembered and reapplied when games are added.",
+		"Release Year comes from an installed DAT or a (1998) tag in the filename.",
+		"Play Time and Last Played put the most played and most recent first.",
+		"Date Added puts the newest first, games added before it was tracked go last.",
+	}
+	options.FooterHelpItems = []gabagool.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "A", HelpText: "Sort"},
+		{ButtonName: "Menu", HelpText: "Help"},
+	}
+
+	selection, err := gabagool.List(options)
+	if err != nil || selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return "", err
+	}
+
+	return selection.Unwrap().SelectedItem.Metadata.(string), nil
+}
+
+// promptCollectionName asks for the name of a new collection, an empty name means the user backed out or it was taken
+func promptCollectionName(defaultName string) (string, error) {
+	name, err := gabagool.Keyboard(defaultName)
+	if err != nil || name.IsNone() {
+		return "", err
+	}
+
+	newName := strings.TrimSpace(name.Unwrap())
+	if newName != "" && utils.DoesFileExists(filepath.Join(utils.GetCollectionDirectory(), newName+".txt")) {
+		utils.ShowTimedMessage(fmt.Sprintf("%s already exists!", newName), time.Second*2)
+		return "", nil
+	}
+
+	return newName, nil
+}
+
+// chooseOtherCollection lists every collection but current, an empty collection is returned when the user backs out
+func chooseOtherCollection(current models.Collection, action sum.Int[models.Action]) (models.Collection, error) {
+	collections, _, err := utils.GenerateCollectionList("", false)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	var menuItems []gabagool.MenuItem
+	for _, collection := range collections {
+		if collection.CollectionFile == current.CollectionFile {
+			continue
+		}
+
+		menuItems = append(menuItems, gabagool.MenuItem{
+			Text:     collection.DisplayName,
+			Selected: false,
+			Focused:  false,
+			Metadata: collection,
+		})
+	}
+
+	if len(menuItems) == 0 {
+		utils.ShowTimedMessage("No other collections!", time.Second*2)
+		return models.Collection{}, nil
+	}
+
+	options := gabagool.DefaultListOptions(fmt.Sprintf("%s: %s", models.ActionNames[action], current.DisplayName), menuItems)
+	options.SmallTitle = true
+	options.FooterHelpItems = []gabagool.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	selection, err := gabagool.List(options)
+	if err != nil || selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return models.Collection{}, err
+	}
+
+	return selection.Unwrap().SelectedItem.Metadata.(models.Collection), nil
+}
+
+// reportDerivedCollection tells the user how the new collection turned out and returns to the collection list
+func reportDerivedCollection(created models.Collection, err error) (interface{}, int, error) {
+	state.ClearCollectionMap()
+
+	if err != nil {
+		common.GetLoggerInstance().Error("failed to create collection", zap.Error(err))
+		utils.ShowTimedMessage(fmt.Sprintf("Unable to create %s!", created.DisplayName), time.Second*2)
+		return nil, -1, err
+	}
+
+	if len(created.Games) == 0 {
+		utils.ShowTimedMessage(fmt.Sprintf("No games to add to %s!", created.DisplayName), time.Second*2)
+		return nil, 2, nil
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Created %s with %d games", created.DisplayName, len(created.Games)), time.Second*2)
+	return nil, 0, nil
+}
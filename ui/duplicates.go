@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"strings"
+	"time"
+)
+
+const (
+	archiveDuplicates = "Archive Others"
+	deleteDuplicates  = "Delete Others"
+)
+
+type DuplicateGroupsScreen struct{}
+
+func InitDuplicateGroupsScreen() DuplicateGroupsScreen {
+	return DuplicateGroupsScreen{}
+}
+
+func (dgs DuplicateGroupsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.DuplicateGroups
+}
+
+// Lists duplicate groups; multi-selecting groups keeps the preferred copy of each and archives or deletes the rest
+func (dgs DuplicateGroupsScreen) Draw() (item interface{}, exitCode int, e error) {
+	collectionMap := state.GetCollectionMap()
+
+	var groups []models.DuplicateGroup
+	var scanErr error
+	gaba.ProcessMessage("Looking for duplicates...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		groups, scanErr = utils.FindDuplicates(collectionMap)
+		return nil, nil
+	})
+
+	if scanErr != nil {
+		utils.ShowTimedMessage("Unable to scan for duplicates!", time.Second*2)
+		return nil, -1, scanErr
+	}
+
+	if len(groups) == 0 {
+		utils.ShowTimedMessage("No duplicates found!", time.Second*2)
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, group := range groups {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s [%s] (%d)", group.Title, group.Reason, len(group.Roms)),
+			Selected: false,
+			Focused:  false,
+			Metadata: group,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%d Duplicate Groups", len(groups)), menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.EnableMultiSelect = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "Menu", HelpText: "Help"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	options.EnableHelp = true
+	options.HelpTitle = "Duplicate Controls"
+	options.HelpText = []string{
+		"• A: Choose which copy to keep",
+		"• Select: Toggle Multi-Select",
+		"• Start: Keep the most played copy of every selected group",
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+	selectedItems := selection.Unwrap().SelectedItems
+
+	if len(selectedItems) == 1 {
+		return selectedItems[0].Metadata.(models.DuplicateGroup), 0, nil
+	}
+
+	action, ok := chooseDuplicateAction(fmt.Sprintf("%d Groups Selected", len(selectedItems)))
+	if !ok {
+		return nil, 4, nil
+	}
+
+	if !utils.ConfirmAction(fmt.Sprintf("Keep the most played copy of %d games and %s?", len(selectedItems), strings.ToLower(action))) {
+		return nil, 4, nil
+	}
+
+	defer state.ClearCollectionMap()
+
+	utils.BeginOperation(fmt.Sprintf("Resolve %d Duplicate Groups", len(selectedItems)))
+	failed := 0
+	for _, selected := range selectedItems {
+		group := selected.Metadata.(models.DuplicateGroup)
+		if err := utils.ResolveDuplicateGroup(group, utils.PreferredDuplicate(group), action == deleteDuplicates, collectionMap); err != nil {
+			failed++
+		}
+	}
+	utils.CommitOperation()
+
+	if failed > 0 {
+		utils.ShowTimedMessage(fmt.Sprintf("%d groups could not be resolved!", failed), time.Second*2)
+	} else {
+		utils.ShowTimedMessage(fmt.Sprintf("Resolved %d duplicate groups!", len(selectedItems)), time.Second*2)
+	}
+
+	return nil, 4, nil
+}
+
+type DuplicateGroupDetailsScreen struct {
+	Group models.DuplicateGroup
+}
+
+func InitDuplicateGroupDetailsScreen(group models.DuplicateGroup) DuplicateGroupDetailsScreen {
+	return DuplicateGroupDetailsScreen{
+		Group: group,
+	}
+}
+
+func (dgds DuplicateGroupDetailsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.DuplicateGroupDetails
+}
+
+// Shows every copy with its size, play time and collections and keeps the selected one
+func (dgds DuplicateGroupDetailsScreen) Draw() (item interface{}, exitCode int, e error) {
+	var menuItems []gaba.MenuItem
+	for i, rom := range dgds.Group.Roms {
+		relativePath, err := filepath.Rel(utils.GetRomDirectory(), rom.Path)
+		if err != nil {
+			relativePath = rom.Path
+		}
+
+		text := fmt.Sprintf("%s | %s | %s", relativePath, utils.FormatBytes(rom.Size), utils.ConvertSecondsToHumanReadableAbbreviated(rom.PlayTime))
+		if len(rom.Collections) > 0 {
+			text = fmt.Sprintf("%s | %s", text, strings.Join(rom.Collections, ", "))
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: i,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%s [%s]", dgds.Group.Title, dgds.Group.Reason), menuItems)
+	options.SmallTitle = true
+	options.SelectedIndex = utils.PreferredDuplicate(dgds.Group)
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Keep"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	keep := selection.Unwrap().SelectedItem.Metadata.(int)
+
+	action, ok := chooseDuplicateAction(fmt.Sprintf("Keep %s", filepath.Base(dgds.Group.Roms[keep].Path)))
+	if !ok {
+		return nil, 2, nil
+	}
+
+	if !utils.ConfirmAction(fmt.Sprintf("Keep %s and %s?", filepath.Base(dgds.Group.Roms[keep].Path), strings.ToLower(action))) {
+		return nil, 2, nil
+	}
+
+	collectionMap := state.GetCollectionMap()
+	defer state.ClearCollectionMap()
+
+	utils.BeginOperation(fmt.Sprintf("Resolve Duplicates of %s", dgds.Group.Title))
+	err = utils.ResolveDuplicateGroup(dgds.Group, keep, action == deleteDuplicates, collectionMap)
+	utils.CommitOperation()
+
+	if err != nil {
+		utils.ShowTimedMessage("Unable to resolve all duplicates!", time.Second*2)
+	} else {
+		utils.ShowTimedMessage(fmt.Sprintf("Kept %s!", filepath.Base(dgds.Group.Roms[keep].Path)), time.Second*2)
+	}
+
+	return nil, 0, nil
+}
+
+func chooseDuplicateAction(title string) (string, bool) {
+	options := gaba.DefaultListOptions(title, []gaba.MenuItem{
+		{Text: archiveDuplicates, Metadata: archiveDuplicates},
+		{Text: deleteDuplicates, Metadata: deleteDuplicates},
+	})
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil || !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return "", false
+	}
+
+	return selection.Unwrap().SelectedItem.Metadata.(string), true
+}
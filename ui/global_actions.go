@@ -163,6 +163,41 @@ func (gas GlobalActionsScreen) Draw() (value interface{}, exitCode int, e error)
 				message := fmt.Sprintf("Art found for %d/%d games!", len(res.CompletedDownloads), selectedMissingArtCount)
 				utils.ShowTimedMessage(message, time.Second*2)
 			}
+		} else if selection.Unwrap().SelectedItem.Metadata == models.Actions.GlobalBackupSaves {
+			backupSaves()
+		} else if selection.Unwrap().SelectedItem.Metadata == models.Actions.GlobalRestoreSaves {
+			return models.Actions.GlobalRestoreSaves, 4, nil
+		} else if selection.Unwrap().SelectedItem.Metadata == models.Actions.GlobalFindDuplicates {
+			return models.Actions.GlobalFindDuplicates, 4, nil
+		} else if selection.Unwrap().SelectedItem.Metadata == models.Actions.GlobalOneGameOneRom {
+			romDirectories, err := utils.GetRomDirectories()
+			if err != nil {
+				utils.ShowTimedMessage("Unable to load ROM directories!", time.Second*2)
+				return nil, 0, nil
+			}
+
+			var platforms []gabagool.MenuItem
+			for _, romDirectory := range romDirectories {
+				platforms = append(platforms, gabagool.MenuItem{
+					Text:     romDirectory.DisplayName,
+					Selected: false,
+					Focused:  false,
+					Metadata: romDirectory,
+				})
+			}
+
+			platformOptions := gabagool.DefaultListOptions("1 Game 1 ROM", platforms)
+			platformOptions.FooterHelpItems = []gabagool.FooterHelpItem{
+				{ButtonName: "B", HelpText: "Back"},
+				{ButtonName: "A", HelpText: "Select"},
+			}
+
+			platform, err := gabagool.List(platformOptions)
+			if err != nil || !platform.IsSome() || platform.Unwrap().SelectedIndex == -1 {
+				return nil, 0, nil
+			}
+
+			return platform.Unwrap().SelectedItem.Metadata.(shared.RomDirectory), 4, nil
 		} else if selection.Unwrap().SelectedItem.Metadata == models.Actions.GlobalClearRecents {
 			confirmClear := utils.ConfirmAction("Are you sure you want to clear your recently played list?\n\nThis cannot be undone!")
 
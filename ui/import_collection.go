@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"strings"
+	"time"
+)
+
+const defaultImportThreshold = .80
+
+type ImportCollectionScreen struct{}
+
+func InitImportCollectionScreen() ImportCollectionScreen {
+	return ImportCollectionScreen{}
+}
+
+func (ics ImportCollectionScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ImportCollection
+}
+
+// Lists the collection exports in the export folder and rebuilds the selected one against this library
+func (ics ImportCollectionScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+	exportDirectory := utils.GetExportDirectory()
+
+	entries, err := utils.GetFileList(exportDirectory)
+	if err != nil {
+		utils.ShowTimedMessage("Unable to read the export folder!", time.Second*2)
+		return nil, -1, err
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, entry := range entries {
+		if entry.IsDir() || !utils.IsCollectionExportFile(entry.Name()) {
+			continue
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     entry.Name(),
+			Selected: false,
+			Focused:  false,
+			Metadata: filepath.Join(exportDirectory, entry.Name()),
+		})
+	}
+
+	if len(menuItems) == 0 {
+		utils.ShowTimedMessage(fmt.Sprintf("No collection exports found!\nCopy them to %s", exportDirectory), time.Second*3)
+		return nil, 404, nil
+	}
+
+	options := gaba.DefaultListOptions("Import Collection", menuItems)
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Import"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	export, err := utils.ReadCollectionExport(selection.Unwrap().SelectedItem.Metadata.(string))
+	if err != nil {
+		logger.Error("Unable to read collection export", zap.Error(err))
+		utils.ShowTimedMessage("Unable to read collection export!", time.Second*2)
+		return nil, -1, err
+	}
+
+	name, err := gaba.Keyboard(export.Name)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if name.IsNone() || strings.TrimSpace(name.Unwrap()) == "" {
+		return nil, 2, nil
+	}
+
+	collectionName := strings.TrimSpace(name.Unwrap())
+	if utils.DoesFileExists(filepath.Join(utils.GetCollectionDirectory(), collectionName+".txt")) &&
+		!utils.ConfirmAction(fmt.Sprintf("Replace the existing collection\n%s?", collectionName)) {
+		return nil, 2, nil
+	}
+
+	threshold := state.GetAppState().Config.FuzzySearchThreshold
+	if threshold == 0 {
+		threshold = defaultImportThreshold
+	}
+
+	var result models.CollectionImportResult
+	gaba.ProcessMessage(fmt.Sprintf("Importing %s...", collectionName), gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		utils.BeginOperation(fmt.Sprintf("Import collection %s", collectionName))
+		defer utils.CommitOperation()
+
+		result, err = utils.ImportCollection(export, collectionName, threshold)
+		return nil, nil
+	})
+	state.ClearCollectionMap()
+
+	if err != nil {
+		logger.Error("Unable to import collection", zap.Error(err))
+		utils.ShowTimedMessage("Unable to import collection!", time.Second*2)
+		return nil, -1, err
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Imported %d of %d games into %s", len(result.Collection.Games), len(export.Games), collectionName), time.Second*3)
+
+	if len(result.Unresolved) > 0 {
+		return nil, 0, showUnresolvedImports(result.Unresolved)
+	}
+
+	return nil, 0, nil
+}
+
+// showUnresolvedImports lists the exported games that have no match in this library
+func showUnresolvedImports(unresolved []models.CollectionExportGame) error {
+	var menuItems []gaba.MenuItem
+	for _, game := range unresolved {
+		text := game.Title
+		if game.Platform != "" {
+			text = fmt.Sprintf("[%s] %s", game.Platform, game.Title)
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%d Games Not Found", len(unresolved)), menuItems)
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Done"},
+	}
+
+	_, err := gaba.List(options)
+	return err
+}
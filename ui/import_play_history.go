@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"slices"
+	"strings"
+	"time"
+)
+
+type ImportPlayHistoryScreen struct{}
+
+func InitImportPlayHistoryScreen() ImportPlayHistoryScreen {
+	return ImportPlayHistoryScreen{}
+}
+
+func (iphs ImportPlayHistoryScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ImportPlayHistory
+}
+
+// Lists the play history exports in the export folder and merges the selected one into the game tracker
+func (iphs ImportPlayHistoryScreen) Draw() (item interface{}, exitCode int, e error) {
+	exportDirectory := utils.GetExportDirectory()
+
+	entries, err := utils.GetFileList(exportDirectory)
+	if err != nil {
+		utils.ShowTimedMessage("Unable to read the export folder!", time.Second*2)
+		return nil, -1, err
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, entry := range entries {
+		if entry.IsDir() || !utils.IsPlayHistoryImportFile(entry.Name()) {
+			continue
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     entry.Name(),
+			Selected: false,
+			Focused:  false,
+			Metadata: filepath.Join(exportDirectory, entry.Name()),
+		})
+	}
+
+	if len(menuItems) == 0 {
+		utils.ShowTimedMessage(fmt.Sprintf("No play history exports found!\nCopy them to %s", exportDirectory), time.Second*3)
+		return nil, 404, nil
+	}
+
+	// newest export first
+	slices.SortFunc(menuItems, func(a, b gaba.MenuItem) int {
+		return strings.Compare(b.Text, a.Text)
+	})
+
+	options := gaba.DefaultListOptions("Import Play History", menuItems)
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Import"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	path := selection.Unwrap().SelectedItem.Metadata.(string)
+	if !utils.ConfirmAction(fmt.Sprintf("Merge play history from %s?", filepath.Base(path))) {
+		return nil, 2, nil
+	}
+
+	var result models.PlayHistoryImportResult
+	var importErr error
+	gaba.ProcessMessage("Importing play history...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		export, err := utils.ReadPlayHistoryExport(path)
+		if err != nil {
+			importErr = err
+			return nil, nil
+		}
+
+		result, importErr = utils.ImportPlayHistory(export)
+		return nil, nil
+	})
+
+	if importErr != nil {
+		utils.ShowTimedMessage("Unable to import play history!", time.Second*2)
+		return nil, -1, importErr
+	}
+
+	state.ClearPlayMaps()
+
+	utils.ShowTimedMessage(fmt.Sprintf("Imported %d sessions!\n%d were already present", result.SessionsAdded, result.SessionsSkipped), time.Second*3)
+	return nil, 0, nil
+}
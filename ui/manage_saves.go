@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"time"
+)
+
+const (
+	saveActionBackup  = "Back Up Saves"
+	saveActionRestore = "Restore Snapshot"
+	saveActionExport  = "Export Saves"
+)
+
+type ManageSavesScreen struct {
+	Game          shared.Item
+	RomDirectory  shared.RomDirectory
+	ActionsScreen *ActionsScreen
+}
+
+func InitManageSavesScreen(game shared.Item, romDirectory shared.RomDirectory, actionsScreen *ActionsScreen) ManageSavesScreen {
+	return ManageSavesScreen{
+		Game:          game,
+		RomDirectory:  romDirectory,
+		ActionsScreen: actionsScreen,
+	}
+}
+
+func (mss ManageSavesScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ManageSaves
+}
+
+// Lists the save files of a game below the backup, restore and export actions; A on a save deletes it
+func (mss ManageSavesScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+
+	saves, err := utils.FindSaveFiles(mss.Game, mss.RomDirectory)
+	if err != nil {
+		logger.Error("Unable to read saves", zap.Error(err))
+		utils.ShowTimedMessage("Unable to read saves!", time.Second*2)
+		return nil, -1, err
+	}
+
+	snapshots, err := utils.ListSaveSnapshots(mss.Game, mss.RomDirectory)
+	if err != nil {
+		logger.Error("Unable to read save snapshots", zap.Error(err))
+	}
+
+	if len(saves) == 0 && len(snapshots) == 0 {
+		utils.ShowTimedMessage("No saves found!", time.Second*2)
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	if len(saves) > 0 {
+		menuItems = append(menuItems, gaba.MenuItem{Text: saveActionBackup, Metadata: saveActionBackup})
+	}
+	if len(snapshots) > 0 {
+		menuItems = append(menuItems, gaba.MenuItem{Text: saveActionRestore, Metadata: saveActionRestore})
+	}
+	if len(saves) > 0 {
+		menuItems = append(menuItems, gaba.MenuItem{Text: saveActionExport, Metadata: saveActionExport})
+	}
+
+	for _, save := range saves {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s : %s : %s", save.ModTime.Format("Jan 02 15:04"), utils.FormatBytes(save.Size), save.Filename),
+			Selected: false,
+			Focused:  false,
+			Metadata: save,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("Saves: %s", mss.Game.DisplayName), menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+
+	switch selected := selection.Unwrap().SelectedItem.Metadata.(type) {
+	case models.SaveFile:
+		mss.deleteSave(selected)
+	case string:
+		switch selected {
+		case saveActionBackup:
+			mss.backupSaves()
+		case saveActionRestore:
+			mss.restoreSnapshot(snapshots)
+		case saveActionExport:
+			mss.exportSaves()
+		}
+	}
+
+	return nil, 0, nil
+}
+
+func (mss ManageSavesScreen) backupSaves() {
+	var snapshot models.SaveSnapshot
+	var err error
+	gaba.ProcessMessage("Backing up saves...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		snapshot, err = utils.BackupSaves(mss.Game, mss.RomDirectory)
+		return nil, nil
+	})
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Unable to back up saves", zap.Error(err))
+		utils.ShowTimedMessage("Unable to back up saves!", time.Second*2)
+		return
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Backed up %d save files", len(snapshot.Files)), time.Second*2)
+}
+
+func (mss ManageSavesScreen) restoreSnapshot(snapshots []models.SaveSnapshot) {
+	var menuItems []gaba.MenuItem
+	for _, snapshot := range snapshots {
+		var size int64
+		for _, file := range snapshot.Files {
+			size += file.Size
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s : %d Files : %s", snapshot.CreatedAt.Format("Jan 02 2006 15:04"), len(snapshot.Files), utils.FormatBytes(size)),
+			Selected: false,
+			Focused:  false,
+			Metadata: snapshot,
+		})
+	}
+
+	options := gaba.DefaultListOptions("Restore Snapshot", menuItems)
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "A", HelpText: "Restore"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil || !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return
+	}
+
+	snapshot := selection.Unwrap().SelectedItem.Metadata.(models.SaveSnapshot)
+	if !utils.ConfirmAction(fmt.Sprintf("Replace the current saves with\nthe %s snapshot?", snapshot.CreatedAt.Format("Jan 02 15:04"))) {
+		return
+	}
+
+	gaba.ProcessMessage("Restoring saves...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		err = utils.RestoreSaveSnapshot(mss.Game, mss.RomDirectory, snapshot)
+		return nil, nil
+	})
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Unable to restore saves", zap.Error(err))
+		utils.ShowTimedMessage("Unable to restore saves!", time.Second*2)
+		return
+	}
+
+	utils.ShowTimedMessage("Saves restored!", time.Second*2)
+}
+
+func (mss ManageSavesScreen) exportSaves() {
+	var exportDirectory string
+	var err error
+	gaba.ProcessMessage("Exporting saves...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		exportDirectory, err = utils.ExportSaves(mss.Game, mss.RomDirectory)
+		return nil, nil
+	})
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Unable to export saves", zap.Error(err))
+		utils.ShowTimedMessage("Unable to export saves!", time.Second*2)
+		return
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Saves exported to\n%s", exportDirectory), time.Second*3)
+}
+
+func (mss ManageSavesScreen) deleteSave(save models.SaveFile) {
+	if !utils.ConfirmAction(fmt.Sprintf("Delete %s?", save.Filename)) {
+		return
+	}
+
+	if err := utils.DeleteSaveFile(mss.Game, save); err != nil {
+		common.GetLoggerInstance().Error("Unable to delete save", zap.Error(err))
+		utils.ShowTimedMessage("Unable to delete save!", time.Second*2)
+		return
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Deleted %s", save.Filename), time.Second*2)
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"github.com/veandco/go-sdl2/sdl"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"time"
+)
+
+type OneGameOneRomScreen struct {
+	RomDirectory         shared.RomDirectory
+	Games                shared.Items
+	PreviousRomDirectory shared.RomDirectory
+	SearchFilter         string
+}
+
+// InitOneGameOneRomScreen proposes archives for the given games, or for the whole platform when games is empty.
+func InitOneGameOneRomScreen(romDirectory shared.RomDirectory, games shared.Items,
+	previousRomDirectory shared.RomDirectory, searchFilter string) OneGameOneRomScreen {
+	return OneGameOneRomScreen{
+		RomDirectory:         romDirectory,
+		Games:                games,
+		PreviousRomDirectory: previousRomDirectory,
+		SearchFilter:         searchFilter,
+	}
+}
+
+func (ogor OneGameOneRomScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.OneGameOneRom
+}
+
+// Lists every non-preferred variant, preselected, and archives the ones left selected
+func (ogor OneGameOneRomScreen) Draw() (item interface{}, exitCode int, e error) {
+	var proposals []models.OneGameOneRomProposal
+	var proposeErr error
+
+	gaba.ProcessMessage(fmt.Sprintf("Comparing %s variants...", ogor.RomDirectory.DisplayName), gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		proposals, proposeErr = utils.ProposeOneGameOneRom(ogor.RomDirectory, ogor.Games, state.GetAppState().Config.RegionPriority)
+		return nil, nil
+	})
+
+	if proposeErr != nil {
+		utils.ShowTimedMessage("Unable to compare ROM variants!", time.Second*2)
+		return nil, -1, proposeErr
+	}
+
+	if len(proposals) == 0 {
+		utils.ShowTimedMessage("Already one ROM per game!", time.Second*2)
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, proposal := range proposals {
+		for _, variant := range proposal.Archive {
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text:     fmt.Sprintf("%s (Keep %s)", filepath.Base(variant), filepath.Base(proposal.Keep)),
+				Selected: true,
+				Focused:  false,
+				Metadata: variant,
+			})
+		}
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("Archive %d Variants?", len(menuItems)), menuItems)
+	options.SmallTitle = true
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+	options.MultiSelectKey = sdl.K_0
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select / Unselect"},
+		{ButtonName: "Start", HelpText: "Archive"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 || len(selection.Unwrap().SelectedItems) == 0 {
+		return nil, 2, nil
+	}
+
+	var variants []string
+	for _, selected := range selection.Unwrap().SelectedItems {
+		variants = append(variants, selected.Metadata.(string))
+	}
+
+	collectionMap := state.GetCollectionMap()
+	defer state.ClearCollectionMap()
+
+	var archived int
+	var archiveErr error
+	gaba.ProcessMessage(fmt.Sprintf("Archiving %d variants...", len(variants)), gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		archived, archiveErr = utils.ArchiveVariants(variants, collectionMap)
+		return nil, nil
+	})
+
+	if archiveErr != nil {
+		utils.ShowTimedMessage(fmt.Sprintf("Archived %d/%d variants!", archived, len(variants)), time.Second*2)
+	} else {
+		utils.ShowTimedMessage(fmt.Sprintf("Archived %d variants!", archived), time.Second*2)
+	}
+
+	return nil, 0, nil
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"time"
+)
+
+type OperationHistoryScreen struct{}
+
+func InitOperationHistoryScreen() OperationHistoryScreen {
+	return OperationHistoryScreen{}
+}
+
+func (ohs OperationHistoryScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.OperationHistory
+}
+
+// Lists journaled operations newest first and undoes the selected one
+func (ohs OperationHistoryScreen) Draw() (item interface{}, exitCode int, e error) {
+	batches, err := utils.GetJournal()
+	if err != nil {
+		utils.ShowTimedMessage("Unable to load operation history!", time.Second*2)
+		return nil, -1, err
+	}
+
+	var menuItems []gaba.MenuItem
+	for i := len(batches) - 1; i >= 0; i-- {
+		batch := batches[i]
+		text := fmt.Sprintf("%s : %s", batch.CreatedAt.Format("Jan 02 15:04"), batch.Description)
+		if batch.Undone {
+			text = text + " (Undone)"
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: batch,
+		})
+	}
+
+	options := gaba.DefaultListOptions("Operation History", menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.EmptyMessage = "No Operations Recorded"
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Undo"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(models.JournalBatch), 0, nil
+	}
+
+	return nil, 2, nil
+}
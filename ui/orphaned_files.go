@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"github.com/veandco/go-sdl2/sdl"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"time"
+)
+
+const (
+	orphanedFilesDelete  = "Delete"
+	orphanedFilesArchive = "Archive"
+)
+
+type OrphanedFilesScreen struct{}
+
+func InitOrphanedFilesScreen() OrphanedFilesScreen {
+	return OrphanedFilesScreen{}
+}
+
+func (ofs OrphanedFilesScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.OrphanedFiles
+}
+
+// Lists art and save files no ROM owns anymore, all selected, and deletes or archives the selection in one go
+func (ofs OrphanedFilesScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+
+	var orphans []models.OrphanedFile
+	var scanErr error
+	gaba.ProcessMessage("Looking for orphaned art and saves...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		orphans, scanErr = utils.FindOrphanedFiles()
+		return nil, nil
+	})
+
+	if scanErr != nil {
+		logger.Error("Unable to scan for orphaned files", zap.Error(scanErr))
+		utils.ShowTimedMessage("Unable to scan for orphaned files!", time.Second*2)
+		return nil, -1, scanErr
+	}
+
+	if len(orphans) == 0 {
+		utils.ShowTimedMessage("No orphaned art or saves!", time.Second*2)
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, orphan := range orphans {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("[%s] %s : %s : %s", orphan.Kind, orphan.Platform, utils.FormatBytes(orphan.Size), filepath.Base(orphan.Path)),
+			Selected: true,
+			Focused:  false,
+			Metadata: orphan,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%d Orphans: %s Reclaimable", len(orphans), utils.FormatBytes(utils.OrphanedFilesSize(orphans))), menuItems)
+	options.SmallTitle = true
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+	options.MultiSelectKey = sdl.K_0
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select / Unselect"},
+		{ButtonName: "Start", HelpText: "Clean Up"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 || len(selection.Unwrap().SelectedItems) == 0 {
+		return nil, 2, nil
+	}
+
+	var selected []models.OrphanedFile
+	for _, item := range selection.Unwrap().SelectedItems {
+		selected = append(selected, item.Metadata.(models.OrphanedFile))
+	}
+
+	archives, err := utils.GetArchiveFileList()
+	if err != nil {
+		logger.Error("Unable to list archives", zap.Error(err))
+	}
+
+	actions := []gaba.MenuItem{{Text: orphanedFilesDelete, Metadata: orphanedFilesDelete}}
+	if len(archives) > 0 {
+		actions = append(actions, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s to %s", orphanedFilesArchive, utils.CleanArchiveName(archives[0])),
+			Metadata: orphanedFilesArchive,
+		})
+	}
+
+	actionOptions := gaba.DefaultListOptions(fmt.Sprintf("%d Files : %s", len(selected), utils.FormatBytes(utils.OrphanedFilesSize(selected))), actions)
+	actionOptions.SmallTitle = true
+	actionOptions.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	action, err := gaba.List(actionOptions)
+	if err != nil || !action.IsSome() || action.Unwrap().SelectedIndex == -1 {
+		return nil, 0, nil
+	}
+
+	done := 0
+	var cleanErr error
+	switch action.Unwrap().SelectedItem.Metadata {
+	case orphanedFilesDelete:
+		if !utils.ConfirmAction(fmt.Sprintf("Delete %d orphaned files?", len(selected))) {
+			return nil, 0, nil
+		}
+
+		gaba.ProcessMessage("Deleting orphaned files...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+			done, cleanErr = utils.DeleteOrphanedFiles(selected)
+			return nil, nil
+		})
+	case orphanedFilesArchive:
+		gaba.ProcessMessage("Archiving orphaned files...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+			done, cleanErr = utils.ArchiveOrphanedFiles(selected, archives[0])
+			return nil, nil
+		})
+	}
+
+	if cleanErr != nil {
+		logger.Error("Unable to clean up orphaned files", zap.Error(cleanErr))
+		utils.ShowTimedMessage(fmt.Sprintf("Cleaned up %d/%d files!", done, len(selected)), time.Second*2)
+	} else {
+		utils.ShowTimedMessage(fmt.Sprintf("Cleaned up %d files!", done), time.Second*2)
+	}
+
+	utils.RefreshStorageReport()
+
+	return nil, 0, nil
+}
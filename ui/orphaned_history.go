@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"slices"
+	"time"
+)
+
+type OrphanedHistoryScreen struct {
+	RomIds        []int
+	ActionsScreen *PlayHistoryActionsScreen
+}
+
+// InitOrphanedHistoryScreen lists every orphan, or only those of romIds when opened from a game's play history actions.
+func InitOrphanedHistoryScreen(romIds []int, actionsScreen *PlayHistoryActionsScreen) OrphanedHistoryScreen {
+	return OrphanedHistoryScreen{
+		RomIds:        romIds,
+		ActionsScreen: actionsScreen,
+	}
+}
+
+func (ohs OrphanedHistoryScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.OrphanedHistory
+}
+
+// Lists play history whose ROM has gone missing; A looks for its new home and X deletes it
+func (ohs OrphanedHistoryScreen) Draw() (item interface{}, exitCode int, e error) {
+	var orphans []models.OrphanedHistory
+	var scanErr error
+	gaba.ProcessMessage("Looking for orphaned play history...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		orphans, scanErr = utils.FindOrphanedHistory()
+		return nil, nil
+	})
+
+	if scanErr != nil {
+		utils.ShowTimedMessage("Unable to read play history!", time.Second*2)
+		return nil, -1, scanErr
+	}
+
+	if len(ohs.RomIds) > 0 {
+		orphans = slices.DeleteFunc(orphans, func(orphan models.OrphanedHistory) bool {
+			return !slices.Contains(ohs.RomIds, orphan.RomId)
+		})
+	}
+
+	if len(orphans) == 0 {
+		// opened for a single game, nothing left to rehome just means it has been dealt with
+		if len(ohs.RomIds) == 0 {
+			utils.ShowTimedMessage("No orphaned play history!", time.Second*2)
+		}
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, orphan := range orphans {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%.1fH : %s", float64(orphan.PlayTime)/3600.0, orphan.TrackerPath),
+			Selected: false,
+			Focused:  false,
+			Metadata: orphan,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%d Orphaned Games", len(orphans)), menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.EnableAction = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "X", HelpText: "Delete"},
+		{ButtonName: "A", HelpText: "Rehome"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+	orphan := selection.Unwrap().SelectedItem.Metadata.(models.OrphanedHistory)
+
+	if !selection.Unwrap().ActionTriggered {
+		return orphan, 0, nil
+	}
+
+	if !utils.ConfirmAction(fmt.Sprintf("Delete %s of play history for %s?", utils.ConvertSecondsToHumanReadableAbbreviated(orphan.PlayTime), orphan.Name)) {
+		return nil, 4, nil
+	}
+
+	utils.BeginOperation(fmt.Sprintf("Delete Play History of %s", orphan.Name))
+	err = utils.DeleteOrphanedHistory(orphan)
+	utils.CommitOperation()
+
+	state.ClearPlayMaps()
+
+	if err != nil {
+		utils.ShowTimedMessage("Unable to delete play history!", time.Second*2)
+	}
+
+	return nil, 4, nil
+}
+
+type RehomeCandidatesScreen struct {
+	Orphan        models.OrphanedHistory
+	ActionsScreen *PlayHistoryActionsScreen
+}
+
+func InitRehomeCandidatesScreen(orphan models.OrphanedHistory, actionsScreen *PlayHistoryActionsScreen) RehomeCandidatesScreen {
+	return RehomeCandidatesScreen{
+		Orphan:        orphan,
+		ActionsScreen: actionsScreen,
+	}
+}
+
+func (rcs RehomeCandidatesScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.RehomeCandidates
+}
+
+// Suggests ROMs on the same platform with a similar title and moves the orphaned history onto the chosen one
+func (rcs RehomeCandidatesScreen) Draw() (item interface{}, exitCode int, e error) {
+	var candidates []models.RehomeCandidate
+	var searchErr error
+	gaba.ProcessMessage("Looking for matching ROMs...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		candidates, searchErr = utils.FindRehomeCandidates(rcs.Orphan)
+		return nil, nil
+	})
+
+	if searchErr != nil {
+		utils.ShowTimedMessage("Unable to search for matching ROMs!", time.Second*2)
+		return nil, -1, searchErr
+	}
+
+	if len(candidates) == 0 {
+		utils.ShowTimedMessage(fmt.Sprintf("No ROMs matching %s found!", rcs.Orphan.Name), time.Second*2)
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, candidate := range candidates {
+		text := fmt.Sprintf("%.0f%% : %s", candidate.Score*100, candidate.TrackerPath)
+		if candidate.HasHistory {
+			text = text + " (Merge)"
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: candidate,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("Rehome %s", rcs.Orphan.Name), menuItems)
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "Menu", HelpText: "Help"},
+		{ButtonName: "A", HelpText: "Rehome"},
+	}
+
+	options.EnableHelp = true
+	options.HelpTitle = "Rehoming Play History"
+	options.HelpText = []string{
+		"• Matches are ROMs on the same platform with a similar name",
+		"• (Merge) => The ROM has play history of its own, sessions are combined",
+		"• Otherwise the history is moved over as is",
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	candidate := selection.Unwrap().SelectedItem.Metadata.(models.RehomeCandidate)
+	if !utils.ConfirmAction(fmt.Sprintf("Move play history of %s to %s?", rcs.Orphan.Name, filepath.Base(candidate.Path))) {
+		return nil, 2, nil
+	}
+
+	utils.BeginOperation(fmt.Sprintf("Rehome Play History of %s", rcs.Orphan.Name))
+	err = utils.RehomeHistory(rcs.Orphan, candidate)
+	utils.CommitOperation()
+
+	state.ClearPlayMaps()
+
+	if err != nil {
+		utils.ShowTimedMessage("Unable to rehome play history!", time.Second*2)
+		return nil, -1, err
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Play history moved to %s!", filepath.Base(candidate.Path)), time.Second*2)
+	return candidate, 0, nil
+}
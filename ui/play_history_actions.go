@@ -2,30 +2,37 @@ package ui
 
 import (
 	"github.com/UncleJunVIP/gabagool/pkg/gabagool"
-	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
-	"go.uber.org/zap"
 	"nextui-game-manager/models"
 	"nextui-game-manager/state"
 	"nextui-game-manager/utils"
 	"qlova.tech/sum"
+	"slices"
 )
 
 type PlayHistoryActionsScreen struct {
-	Game                 shared.Item
-	RomDirectory         shared.RomDirectory
-	SearchFilter         string
-	PreviousRomDirectory shared.RomDirectory
+	Console               string
+	SearchFilter          string
+	GameAggregate         models.PlayHistoryAggregate
+	Game                  shared.Item
+	RomDirectory          shared.RomDirectory
+	PreviousRomDirectory  shared.RomDirectory
+	PlayHistoryOrigin     bool
+	PlayHistoryFilterList []models.PlayHistorySearchFilter
 }
 
-func InitPlayHistoryActionsScreen(game shared.Item, romDirectory shared.RomDirectory,
-	previousRomDirectory shared.RomDirectory,
-	searchFilter string) PlayHistoryActionsScreen {
+func InitPlayHistoryActionsScreen(console string, searchFilter string, gameAggregate models.PlayHistoryAggregate, game shared.Item,
+	romDirectory shared.RomDirectory, previousRomDirectory shared.RomDirectory, playHistoryOrigin bool,
+	filterList []models.PlayHistorySearchFilter) PlayHistoryActionsScreen {
 	return PlayHistoryActionsScreen{
-		Game:                 game,
-		RomDirectory:         romDirectory,
-		PreviousRomDirectory: previousRomDirectory,
-		SearchFilter:         searchFilter,
+		Console:               console,
+		SearchFilter:          searchFilter,
+		GameAggregate:         gameAggregate,
+		Game:                  game,
+		RomDirectory:          romDirectory,
+		PreviousRomDirectory:  previousRomDirectory,
+		PlayHistoryOrigin:     playHistoryOrigin,
+		PlayHistoryFilterList: filterList,
 	}
 }
 
@@ -33,20 +40,14 @@ func (ptas PlayHistoryActionsScreen) Name() sum.Int[models.ScreenName] {
 	return models.ScreenNames.PlayHistoryActions
 }
 
+// Actions on the play history of a single game; rehoming is only offered when its ROM is missing
 func (ptas PlayHistoryActionsScreen) Draw() (action interface{}, exitCode int, e error) {
-	logger := common.GetLoggerInstance()
+	actions := models.PlayHistoryActionKeys
 
-	existingArtFilename, err := utils.FindExistingArt(ptas.Game.Filename, ptas.RomDirectory)
-	if err != nil {
-		logger.Error("failed to find existing arts", zap.Error(err))
-	}
-
-	actions := models.ActionKeys
-
-	if existingArtFilename == "" {
-		actions = utils.InsertIntoSlice(actions, 1, "Download Art")
-	} else {
-		actions = utils.InsertIntoSlice(actions, 1, "Delete Art")
+	if utils.FindRomHomeFromAggregate(ptas.GameAggregate, false) != "(-) " {
+		actions = slices.DeleteFunc(slices.Clone(actions), func(action string) bool {
+			return models.ActionMap[action] == models.Actions.PlayHistoryAdopt
+		})
 	}
 
 	var actionEntries []gabagool.MenuItem
@@ -59,7 +60,7 @@ func (ptas PlayHistoryActionsScreen) Draw() (action interface{}, exitCode int, e
 		})
 	}
 
-	options := gabagool.DefaultListOptions(ptas.Game.DisplayName, actionEntries)
+	options := gabagool.DefaultListOptions(ptas.GameAggregate.Name, actionEntries)
 
 	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
 	options.SelectedIndex = selectedIndex
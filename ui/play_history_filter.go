@@ -67,16 +67,12 @@ func (phfs PlayHistoryFilterScreen) Draw() (item interface{}, exitCode int, e er
 		title = title + ": " + currentFilter.DisplayName
 	}
 
-	romIds := []int{}
+	scope := models.PlayHistoryQuery{}
 	if len(phfs.GameAggregate.Id) > 0 {
-		romIds = phfs.GameAggregate.Id
+		scope.RomIds = phfs.GameAggregate.Id
 		title = title + " (" + phfs.GameAggregate.Name + ")"
 	} else if phfs.Console != "" {
-		gamePlayMap, _, _ := state.GetPlayMaps()
-		gamesList := gamePlayMap[phfs.Console]
-		for _, game := range gamesList {
-			romIds = append(romIds, game.Id...)
-		}
+		scope.Consoles = []string{phfs.Console}
 
 		startIndex := strings.LastIndex(phfs.Console, "(")
 		endIndex := strings.LastIndex(phfs.Console, ")")
@@ -89,7 +85,11 @@ func (phfs PlayHistoryFilterScreen) Draw() (item interface{}, exitCode int, e er
 
 	filterList := []models.PlayHistorySearchFilter{}
 	if currentFilter.FilterType < 2 {
-		filterList = utils.GenFiltersList(romIds, currentFilter.SqlFilter, currentFilter.FilterType)
+		var err error
+		filterList, err = utils.GenFiltersList(utils.NarrowPlayHistoryQuery(currentFilter.Query, scope), currentFilter.FilterType)
+		if err != nil {
+			return nil, -1, err
+		}
 	}
 
 	var menuItems []gaba.MenuItem
@@ -41,17 +41,21 @@ func (ptghs PlayHistoryGameHistoryScreen) Name() sum.Int[models.ScreenName] {
 }
 
 func (ptghs PlayHistoryGameHistoryScreen) Draw() (item interface{}, exitCode int, e error) {
-	var playHistory []models.PlayHistoryGranular
+	query := models.PlayHistoryQuery{RomIds: ptghs.GameAggregate.Id}
 	var title string
 	if len(ptghs.PlayHistoryFilterList) == 0 {
-		playHistory = utils.GenerateSingleGameGranularRecords(ptghs.GameAggregate.Id, "")
 		title = ptghs.GameAggregate.Name
 	} else {
 		currentFilter := ptghs.PlayHistoryFilterList[len(ptghs.PlayHistoryFilterList)-1]
-		playHistory = utils.GenerateSingleGameGranularRecords(ptghs.GameAggregate.Id, currentFilter.SqlFilter)
+		query = utils.NarrowPlayHistoryQuery(currentFilter.Query, query)
 		title = fmt.Sprintf("%s: %s", currentFilter.DisplayName, ptghs.GameAggregate.Name)
 	}
 
+	playHistory, err := utils.GenerateSingleGameGranularRecords(query)
+	if err != nil {
+		return nil, -1, err
+	}
+
 	var menuItems []gaba.MenuItem
 	for _, playRecord := range playHistory {
 		duration := utils.ConvertSecondsToHumanReadableAbbreviated(playRecord.PlayTime)
@@ -77,7 +81,7 @@ func (ptghs PlayHistoryGameHistoryScreen) Draw() (item interface{}, exitCode int
 	options.FooterHelpItems = []gaba.FooterHelpItem{
 		{ButtonName: "B", HelpText: "Back"},
 		{ButtonName: "X", HelpText: "Filter"},
-		//{ButtonName: "A", HelpText: "Update"},
+		{ButtonName: "A", HelpText: "Actions"},
 	}
 
 	selection, err := gaba.List(options)
@@ -36,7 +36,11 @@ func (ptgls PlayHistoryGamesListScreen) Draw() (item interface{}, exitCode int,
 		title = fmt.Sprintf("%.1fH : %s", float64(consoleMap[ptgls.Console])/3600.0, ptgls.Console)
 	} else {
 		currentFilter := ptgls.PlayHistoryFilterList[len(ptgls.PlayHistoryFilterList)-1]
-		gamePlayMap, consoleMap, _ = utils.GenerateCurrentGameStats(currentFilter.SqlFilter)
+		var err error
+		gamePlayMap, consoleMap, _, err = utils.GenerateCurrentGameStats(currentFilter.Query)
+		if err != nil {
+			return nil, -1, err
+		}
 		title = fmt.Sprintf("%s: %s", currentFilter.DisplayName, ptgls.Console)
 	}
 
@@ -36,7 +36,11 @@ func (ptls PlayHistoryListScreen) Draw() (item interface{}, exitCode int, e erro
 		title = fmt.Sprintf("%.1f Total Hours Played", float64(totalPlay)/3600.0)
 	} else {
 		currentFilter := ptls.PlayHistoryFilterList[len(ptls.PlayHistoryFilterList)-1]
-		_, consolePlayMap, totalPlay = utils.GenerateCurrentGameStats(currentFilter.SqlFilter)
+		var err error
+		_, consolePlayMap, totalPlay, err = utils.GenerateCurrentGameStats(currentFilter.Query)
+		if err != nil {
+			return nil, -1, err
+		}
 		title = fmt.Sprintf("%s: %.1f Total Hours Played", currentFilter.DisplayName, float64(totalPlay)/3600.0)
 	}
 
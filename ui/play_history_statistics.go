@@ -0,0 +1,59 @@
+package ui
+
+import (
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"time"
+)
+
+type PlayHistoryStatisticsScreen struct{}
+
+func InitPlayHistoryStatisticsScreen() PlayHistoryStatisticsScreen {
+	return PlayHistoryStatisticsScreen{}
+}
+
+func (phss PlayHistoryStatisticsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.PlayHistoryStatistics
+}
+
+// Renders streaks, the weekly heatmap, monthly favourites and abandoned games to an image and shows it
+func (phss PlayHistoryStatisticsScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+
+	var stats models.PlayHistoryStatistics
+	var err error
+	chartPath := utils.GetStatisticsChartPath()
+	gaba.ProcessMessage("Crunching play history...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		stats, err = utils.GeneratePlayHistoryStatistics(models.PlayHistoryQuery{}, time.Now())
+		if err == nil && stats.SessionCount > 0 {
+			err = utils.RenderPlayHistoryStatistics(stats, "Play Statistics", chartPath)
+		}
+		return nil, nil
+	})
+
+	if err != nil {
+		logger.Error("Unable to generate play statistics", zap.Error(err))
+		utils.ShowTimedMessage("Unable to generate play statistics!", time.Second*2)
+		return nil, -1, err
+	}
+
+	if stats.SessionCount == 0 {
+		utils.ShowTimedMessage("No play history yet!", time.Second*2)
+		return nil, 404, nil
+	}
+
+	_, err = gaba.ConfirmationMessage("", []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+	}, gaba.MessageOptions{
+		ImagePath: chartPath,
+	})
+	if err != nil {
+		return nil, -1, err
+	}
+
+	return nil, 2, nil
+}
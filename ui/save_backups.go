@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"time"
+)
+
+const saveBackupRestoreEverything = "Restore Everything"
+
+// backupSaves zips every save and save state into the backup folder from Settings
+func backupSaves() {
+	config := state.GetAppState().Config
+	directory := utils.GetSaveBackupDirectory(config.SaveBackupDirectory)
+
+	var backup models.SaveBackup
+	var err error
+	gaba.ProcessMessage("Backing up saves...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		backup, err = utils.CreateSaveBackup(directory, config.SaveBackupKeep)
+		return nil, nil
+	})
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Unable to back up saves", zap.Error(err))
+		utils.ShowTimedMessage("Unable to back up saves!", time.Second*2)
+		return
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Saves backed up and verified!\n%s", utils.FormatBytes(backup.Size)), time.Second*2)
+}
+
+type SaveBackupsScreen struct{}
+
+func InitSaveBackupsScreen() SaveBackupsScreen {
+	return SaveBackupsScreen{}
+}
+
+func (sbs SaveBackupsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.SaveBackups
+}
+
+// Lists the save backups, newest first; A picks everything or a single game to restore from the selected backup
+func (sbs SaveBackupsScreen) Draw() (item interface{}, exitCode int, e error) {
+	directory := utils.GetSaveBackupDirectory(state.GetAppState().Config.SaveBackupDirectory)
+
+	backups, err := utils.ListSaveBackups(directory)
+	if err != nil {
+		utils.ShowTimedMessage("Unable to read the backup folder!", time.Second*2)
+		return nil, -1, err
+	}
+
+	if len(backups) == 0 {
+		utils.ShowTimedMessage(fmt.Sprintf("No save backups found in\n%s", directory), time.Second*3)
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, backup := range backups {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s : %s", backup.CreatedAt.Format("Jan 02 2006 15:04"), utils.FormatBytes(backup.Size)),
+			Selected: false,
+			Focused:  false,
+			Metadata: backup,
+		})
+	}
+
+	options := gaba.DefaultListOptions("Restore Saves", menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Open"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+
+	return nil, 0, restoreSaveBackup(selection.Unwrap().SelectedItem.Metadata.(models.SaveBackup))
+}
+
+// restoreSaveBackup lists the games in a backup under a Restore Everything entry and extracts the chosen files
+func restoreSaveBackup(backup models.SaveBackup) error {
+	logger := common.GetLoggerInstance()
+
+	games, err := utils.ReadSaveBackupGames(backup)
+	if err != nil {
+		logger.Error("Unable to read save backup", zap.Error(err))
+		utils.ShowTimedMessage("Unable to read the backup!", time.Second*2)
+		return nil
+	}
+
+	menuItems := []gaba.MenuItem{{Text: saveBackupRestoreEverything, Metadata: saveBackupRestoreEverything}}
+	for _, game := range games {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("[%s] %s", game.Tag, game.Name),
+			Selected: false,
+			Focused:  false,
+			Metadata: game,
+		})
+	}
+
+	options := gaba.DefaultListOptions(backup.CreatedAt.Format("Jan 02 2006 15:04"), menuItems)
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Restore"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil
+	}
+
+	var files []string
+	message := "Restore every save and save state\nfrom this backup?"
+	if game, isGame := selection.Unwrap().SelectedItem.Metadata.(models.SaveBackupGame); isGame {
+		files = game.Files
+		message = fmt.Sprintf("Restore the saves of\n%s?", game.Name)
+	}
+
+	if !utils.ConfirmAction(message) {
+		return nil
+	}
+
+	restored := 0
+	gaba.ProcessMessage("Restoring saves...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		restored, err = utils.RestoreSaveBackup(backup, files)
+		return nil, nil
+	})
+
+	if err != nil {
+		logger.Error("Unable to restore save backup", zap.Error(err))
+		utils.ShowTimedMessage("Unable to restore saves!", time.Second*2)
+		return nil
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Restored %d files!", restored), time.Second*2)
+	return nil
+}
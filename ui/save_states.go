@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"time"
+)
+
+const saveStateActionDeleteAll = "Delete All Slots"
+
+type SaveStatesScreen struct {
+	Game          shared.Item
+	RomDirectory  shared.RomDirectory
+	ActionsScreen *ActionsScreen
+}
+
+func InitSaveStatesScreen(game shared.Item, romDirectory shared.RomDirectory, actionsScreen *ActionsScreen) SaveStatesScreen {
+	return SaveStatesScreen{
+		Game:          game,
+		RomDirectory:  romDirectory,
+		ActionsScreen: actionsScreen,
+	}
+}
+
+func (sss SaveStatesScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.SaveStates
+}
+
+// Lists the state slots of a game; A shows the slot with its preview and X on the preview deletes it
+func (sss SaveStatesScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+
+	saveStates, err := utils.FindSaveStates(sss.Game, sss.RomDirectory)
+	if err != nil {
+		logger.Error("Unable to read save states", zap.Error(err))
+		utils.ShowTimedMessage("Unable to read save states!", time.Second*2)
+		return nil, -1, err
+	}
+
+	if len(saveStates) == 0 {
+		return nil, 404, nil
+	}
+
+	menuItems := []gaba.MenuItem{{Text: saveStateActionDeleteAll, Metadata: saveStateActionDeleteAll}}
+	for _, saveState := range saveStates {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s : %s : %s", saveStateSlotName(saveState), saveState.ModTime.Format("Jan 02 15:04"), utils.FormatBytes(saveState.Size)),
+			Selected: false,
+			Focused:  false,
+			Metadata: saveState,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("States: %s", sss.Game.DisplayName), menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "View"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+
+	saveState, isSlot := selection.Unwrap().SelectedItem.Metadata.(models.SaveState)
+	if !isSlot {
+		if utils.ConfirmAction(fmt.Sprintf("Delete all %d save states\nof %s?", len(saveStates), sss.Game.DisplayName)) {
+			_, err := utils.ClearSaveStates(sss.Game, sss.RomDirectory)
+			reportSaveStateDeletion(err)
+		}
+		return nil, 0, nil
+	}
+
+	if viewSaveState(saveState) {
+		reportSaveStateDeletion(utils.DeleteSaveState(sss.Game, saveState))
+	}
+
+	return nil, 0, nil
+}
+
+func saveStateSlotName(saveState models.SaveState) string {
+	if saveState.Slot == models.SaveStateAutoResumeSlot {
+		return "Auto Resume"
+	}
+	return fmt.Sprintf("Slot %d", saveState.Slot+1)
+}
+
+// viewSaveState shows the slot with its preview screenshot and reports whether it should be deleted
+func viewSaveState(saveState models.SaveState) bool {
+	message := fmt.Sprintf("%s\n%s : %s", saveStateSlotName(saveState), saveState.ModTime.Format("Jan 02 2006 15:04"), utils.FormatBytes(saveState.Size))
+	if saveState.PreviewPath == "" {
+		message += "\nNo preview available"
+	}
+
+	result, err := gaba.ConfirmationMessage(message, []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "X", HelpText: "Delete"},
+	}, gaba.MessageOptions{
+		ImagePath:     saveState.PreviewPath,
+		ConfirmButton: gaba.ButtonX,
+	})
+
+	return err == nil && result.IsSome() && !result.Unwrap().Cancelled
+}
+
+func reportSaveStateDeletion(err error) {
+	if err != nil {
+		common.GetLoggerInstance().Error("Unable to delete save states", zap.Error(err))
+		utils.ShowTimedMessage("Unable to delete save states!", time.Second*2)
+		return
+	}
+
+	utils.ShowTimedMessage("Save states deleted!", time.Second*2)
+}
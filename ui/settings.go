@@ -10,6 +10,8 @@ import (
 	"nextui-game-manager/state"
 	"nextui-game-manager/utils"
 	"qlova.tech/sum"
+	"slices"
+	"strings"
 )
 
 type SettingsScreen struct {
@@ -166,6 +168,27 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 				}
 			}(),
 		},
+		regionPriorityOption(appState.Config.RegionPriority),
+		backlogCollectionOption(appState.Config.BacklogCompletedCollection),
+		{
+			Item: gabagool.MenuItem{Text: "Play History Collections"},
+			Options: []gabagool.Option{
+				{DisplayName: "True", Value: true},
+				{DisplayName: "False", Value: false},
+			},
+			SelectedOption: func() int {
+				switch appState.Config.AutoCollections {
+				case true:
+					return 0
+				default:
+					return 1
+				}
+			}(),
+		},
+		saveBackupIntervalOption(appState.Config.SaveBackupIntervalDays),
+		saveBackupDirectoryOption(appState.Config.SaveBackupDirectory),
+		saveBackupKeepOption(appState.Config.SaveBackupKeep),
+		trashRetentionOption(appState.Config.TrashRetentionDays),
 	}
 
 	footerHelpItems := []gabagool.FooterHelpItem{
@@ -214,6 +237,23 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 				appState.Config.PlayHistoryShowArchives = option.Options[option.SelectedOption].Value.(bool)
 			} else if option.Item.Text == "Play History Show Collection Tags" {
 				appState.Config.PlayHistoryShowCollections = option.Options[option.SelectedOption].Value.(bool)
+			} else if option.Item.Text == "1G1R Region Priority" {
+				appState.Config.RegionPriority = option.Options[option.SelectedOption].Value.([]string)
+			} else if option.Item.Text == "Completed Backlog Collection" {
+				appState.Config.BacklogCompletedCollection = option.Options[option.SelectedOption].Value.(string)
+			} else if option.Item.Text == "Play History Collections" {
+				appState.Config.AutoCollections = option.Options[option.SelectedOption].Value.(bool)
+				if !appState.Config.AutoCollections {
+					utils.RemoveAutoCollections()
+				}
+			} else if option.Item.Text == "Auto Backup Saves" {
+				appState.Config.SaveBackupIntervalDays = option.Options[option.SelectedOption].Value.(int)
+			} else if option.Item.Text == "Save Backup Folder" {
+				appState.Config.SaveBackupDirectory = option.Options[option.SelectedOption].Value.(string)
+			} else if option.Item.Text == "Save Backups to Keep" {
+				appState.Config.SaveBackupKeep = option.Options[option.SelectedOption].Value.(int)
+			} else if option.Item.Text == "Keep Deleted Files" {
+				appState.Config.TrashRetentionDays = option.Options[option.SelectedOption].Value.(int)
 			}
 		}
 
@@ -230,3 +270,169 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 
 	return nil, 2, nil
 }
+
+var regionPriorityPresets = [][]string{
+	utils.DefaultRegionPriority,
+	{"Europe", "World", "USA", "Japan"},
+	{"Japan", "World", "USA", "Europe"},
+	{"World", "USA", "Europe", "Japan"},
+}
+
+// Custom orders set in config.yml are kept as an extra option so saving settings does not reset them
+func regionPriorityOption(current []string) gabagool.ItemWithOptions {
+	presets := slices.Clone(regionPriorityPresets)
+	if len(current) > 0 && !slices.ContainsFunc(presets, func(preset []string) bool { return slices.Equal(preset, current) }) {
+		presets = append(presets, current)
+	}
+
+	var options []gabagool.Option
+	selected := 0
+	for i, preset := range presets {
+		options = append(options, gabagool.Option{DisplayName: strings.Join(preset, " > "), Value: preset})
+		if slices.Equal(preset, current) {
+			selected = i
+		}
+	}
+
+	return gabagool.ItemWithOptions{
+		Item:           gabagool.MenuItem{Text: "1G1R Region Priority"},
+		Options:        options,
+		SelectedOption: selected,
+	}
+}
+
+// Completed backlog games can be collected automatically; the named collection is created on first use
+func backlogCollectionOption(current string) gabagool.ItemWithOptions {
+	names := []string{""}
+	collections, _, _ := utils.GenerateCollectionList("", false)
+	for _, collection := range collections {
+		names = append(names, collection.DisplayName)
+	}
+	if current != "" && !slices.Contains(names, current) {
+		names = append(names, current)
+	}
+
+	var options []gabagool.Option
+	selected := 0
+	for i, name := range names {
+		displayName := name
+		if name == "" {
+			displayName = "Disabled"
+		}
+		options = append(options, gabagool.Option{DisplayName: displayName, Value: name})
+		if name == current {
+			selected = i
+		}
+	}
+
+	return gabagool.ItemWithOptions{
+		Item:           gabagool.MenuItem{Text: "Completed Backlog Collection"},
+		Options:        options,
+		SelectedOption: selected,
+	}
+}
+
+// Saves are backed up when Game Manager starts and the newest backup is older than the chosen number of days
+func saveBackupIntervalOption(current int) gabagool.ItemWithOptions {
+	options := []gabagool.Option{
+		{DisplayName: "Disabled", Value: 0},
+		{DisplayName: "Daily", Value: 1},
+		{DisplayName: "Every 3 Days", Value: 3},
+		{DisplayName: "Weekly", Value: 7},
+		{DisplayName: "Monthly", Value: 30},
+	}
+
+	selected := 0
+	for i, option := range options {
+		if option.Value.(int) == current {
+			selected = i
+		}
+	}
+
+	return gabagool.ItemWithOptions{
+		Item:           gabagool.MenuItem{Text: "Auto Backup Saves"},
+		Options:        options,
+		SelectedOption: selected,
+	}
+}
+
+// Backups go to the SD card unless a removable drive is picked, a folder set in config.yml is kept as an option
+func saveBackupDirectoryOption(current string) gabagool.ItemWithOptions {
+	directories := utils.SaveBackupDirectoryChoices()
+	if current != "" && !slices.Contains(directories, current) {
+		directories = append(directories, current)
+	}
+
+	var options []gabagool.Option
+	selected := 0
+	for i, directory := range directories {
+		displayName := directory
+		if directory == "" {
+			displayName = "SD Card"
+		}
+		options = append(options, gabagool.Option{DisplayName: displayName, Value: directory})
+		if directory == current {
+			selected = i
+		}
+	}
+
+	return gabagool.ItemWithOptions{
+		Item:           gabagool.MenuItem{Text: "Save Backup Folder"},
+		Options:        options,
+		SelectedOption: selected,
+	}
+}
+
+func saveBackupKeepOption(current int) gabagool.ItemWithOptions {
+	if current <= 0 {
+		current = utils.DefaultSaveBackupKeep
+	}
+
+	counts := []int{3, 5, 10, 20}
+	if !slices.Contains(counts, current) {
+		counts = append(counts, current)
+	}
+
+	var options []gabagool.Option
+	selected := 0
+	for i, count := range counts {
+		options = append(options, gabagool.Option{DisplayName: fmt.Sprintf("%d Backups", count), Value: count})
+		if count == current {
+			selected = i
+		}
+	}
+
+	return gabagool.ItemWithOptions{
+		Item:           gabagool.MenuItem{Text: "Save Backups to Keep"},
+		Options:        options,
+		SelectedOption: selected,
+	}
+}
+
+// Deleted files wait in the trash so they can be undone, after the chosen number of days they are deleted for good
+func trashRetentionOption(current int) gabagool.ItemWithOptions {
+	if current == 0 {
+		current = utils.DefaultTrashRetentionDays
+	}
+
+	options := []gabagool.Option{
+		{DisplayName: "1 Day", Value: 1},
+		{DisplayName: "7 Days", Value: 7},
+		{DisplayName: "14 Days", Value: 14},
+		{DisplayName: "30 Days", Value: 30},
+		{DisplayName: "Until Emptied", Value: -1},
+	}
+
+	selected := 0
+	for i, option := range options {
+		if option.Value.(int) == current {
+			selected = i
+		}
+	}
+
+	return gabagool.ItemWithOptions{
+		Item:           gabagool.MenuItem{Text: "Keep Deleted Files"},
+		Options:        options,
+		SelectedOption: selected,
+	}
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"time"
+)
+
+type SmartCollectionsScreen struct{}
+
+func InitSmartCollectionsScreen() SmartCollectionsScreen {
+	return SmartCollectionsScreen{}
+}
+
+func (scs SmartCollectionsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.SmartCollections
+}
+
+// Lists the smart collections from smart_collections.yml, A rebuilds the selected one and X rebuilds them all
+func (scs SmartCollectionsScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+
+	file, err := utils.LoadSmartCollections()
+	if err != nil {
+		logger.Error("Unable to load smart collections", zap.Error(err))
+		utils.ShowTimedMessage("Unable to read smart_collections.yml!", time.Second*2)
+		return nil, -1, err
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, smartCollection := range file.Collections {
+		text := smartCollection.Name
+		if smartCollection.RefreshOnLaunch {
+			text = "[Auto] " + text
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: smartCollection,
+		})
+	}
+
+	options := gaba.DefaultListOptions("Smart Collections", menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.EnableAction = true
+	options.EnableHelp = true
+	options.HelpTitle = "Smart Collections"
+	options.HelpText = []string{
+		"Smart collections are defined in smart_collections.yml.",
+		"[Auto] collections also refresh each time Game Manager starts.",
+		"• A: Refresh Selected",
+		"• X: Refresh All",
+	}
+	options.EmptyMessage = "Define smart collections in smart_collections.yml"
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "X", HelpText: "Refresh All"},
+		{ButtonName: "A", HelpText: "Refresh"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+
+	collections := file.Collections
+	description := "Refresh all smart collections"
+	if !selection.Unwrap().ActionTriggered {
+		smartCollection := selection.Unwrap().SelectedItem.Metadata.(models.SmartCollection)
+		collections = []models.SmartCollection{smartCollection}
+		description = fmt.Sprintf("Refresh smart collection %s", smartCollection.Name)
+	}
+
+	var counts map[string]int
+	gaba.ProcessMessage("Refreshing smart collections...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		utils.BeginOperation(description)
+		defer utils.CommitOperation()
+
+		counts, err = utils.RefreshSmartCollections(collections, time.Now())
+		return nil, nil
+	})
+	state.ClearCollectionMap()
+
+	if err != nil {
+		logger.Error("Unable to refresh smart collections", zap.Error(err))
+		utils.ShowTimedMessage("Some smart collections could not be refreshed!\nCheck their rules and names.", time.Second*2)
+		return nil, -1, err
+	}
+
+	if len(collections) == 1 {
+		utils.ShowTimedMessage(fmt.Sprintf("%s: %d games", collections[0].Name, counts[collections[0].Name]), time.Second*2)
+	} else {
+		utils.ShowTimedMessage(fmt.Sprintf("Refreshed %d smart collections", len(counts)), time.Second*2)
+	}
+
+	return nil, 0, nil
+}
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"slices"
+	"time"
+)
+
+const (
+	storageArchiveRom = "Archive ROM"
+	storageDeleteRom  = "Delete ROM"
+)
+
+type StorageUsageScreen struct{}
+
+func InitStorageUsageScreen() StorageUsageScreen {
+	return StorageUsageScreen{}
+}
+
+func (sus StorageUsageScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.StorageUsage
+}
+
+// Shows the cached report straight away and refreshes it in the background; only the first visit has to wait
+func (sus StorageUsageScreen) Draw() (item interface{}, exitCode int, e error) {
+	refresh := utils.RefreshStorageReport()
+
+	report, cached := utils.GetCachedStorageReport()
+	if !cached {
+		gaba.ProcessMessage("Calculating storage usage...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+			<-refresh
+			return nil, nil
+		})
+
+		report, cached = utils.GetCachedStorageReport()
+		if !cached {
+			utils.ShowTimedMessage("Unable to calculate storage usage!", time.Second*2)
+			return nil, -1, nil
+		}
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, entry := range report.Entries {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("[%s] %s | %s | %d Files", entry.Kind, entry.Name, utils.FormatBytes(entry.Size), entry.FileCount),
+			Selected: false,
+			Focused:  false,
+			Metadata: entry,
+		})
+	}
+
+	title := fmt.Sprintf("Storage: %s (%s)", utils.FormatBytes(report.Total), report.GeneratedAt.Format("Jan 02 15:04"))
+	if utils.IsStorageReportRefreshing() {
+		title = fmt.Sprintf("Storage: %s (Updating...)", utils.FormatBytes(report.Total))
+	}
+
+	options := gaba.DefaultListOptions(title, menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.SmallTitle = true
+	options.EnableAction = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "X", HelpText: "Reload"},
+		{ButtonName: "A", HelpText: "Details"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsSome() && selection.Unwrap().ActionTriggered {
+		return nil, 4, nil
+	} else if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(models.StorageUsage), 0, nil
+	}
+
+	return nil, 2, nil
+}
+
+type StorageDetailsScreen struct {
+	Usage models.StorageUsage
+}
+
+func InitStorageDetailsScreen(usage models.StorageUsage) StorageDetailsScreen {
+	return StorageDetailsScreen{
+		Usage: usage,
+	}
+}
+
+func (sds StorageDetailsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.StorageDetails
+}
+
+// Lists the largest items of a folder with shortcuts to archive or delete the biggest ROMs
+func (sds StorageDetailsScreen) Draw() (item interface{}, exitCode int, e error) {
+	var menuItems []gaba.MenuItem
+	for _, largest := range sds.Usage.Largest {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s | %s", filepath.Base(largest.Path), utils.FormatBytes(largest.Size)),
+			Selected: false,
+			Focused:  false,
+			Metadata: largest,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%s %s: %s", sds.Usage.Name, sds.Usage.Kind, utils.FormatBytes(sds.Usage.Size)), menuItems)
+	options.SmallTitle = true
+	options.EmptyMessage = "Nothing Here"
+
+	manageable := sds.Usage.Kind == models.StorageKindPlatform || sds.Usage.Kind == models.StorageKindArchive
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+	}
+	if manageable {
+		options.FooterHelpItems = append(options.FooterHelpItems, gaba.FooterHelpItem{ButtonName: "A", HelpText: "Manage"})
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	if !manageable {
+		return sds.Usage, 4, nil
+	}
+
+	selected := selection.Unwrap().SelectedItem.Metadata.(models.StorageItem)
+
+	actions := []gaba.MenuItem{{Text: storageDeleteRom, Metadata: storageDeleteRom}}
+	if sds.Usage.Kind == models.StorageKindPlatform {
+		actions = slices.Insert(actions, 0, gaba.MenuItem{Text: storageArchiveRom, Metadata: storageArchiveRom})
+	}
+
+	actionOptions := gaba.DefaultListOptions(filepath.Base(selected.Path), actions)
+	actionOptions.SmallTitle = true
+	actionOptions.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	action, err := gaba.List(actionOptions)
+	if err != nil || !action.IsSome() || action.Unwrap().SelectedIndex == -1 {
+		return sds.Usage, 4, nil
+	}
+
+	game, romDirectory, err := utils.ResolveRomPath(selected.Path)
+	if err != nil {
+		utils.ShowTimedMessage("Unable to find ROM!", time.Second*2)
+		return sds.Usage, 4, nil
+	}
+
+	collectionMap := state.GetCollectionMap()
+	defer state.ClearCollectionMap()
+
+	switch action.Unwrap().SelectedItem.Metadata {
+	case storageArchiveRom:
+		archives, err := utils.GetArchiveFileList()
+		if err != nil || !utils.ConfirmAction(fmt.Sprintf("Archive %s into %s?", game.DisplayName, utils.CleanArchiveName(archives[0]))) {
+			return sds.Usage, 4, nil
+		}
+
+		if err := utils.ArchiveRom(game, romDirectory, archives[0], collectionMap); err != nil {
+			utils.ShowTimedMessage("Unable to archive ROM!", time.Second*2)
+			return sds.Usage, 4, nil
+		}
+	case storageDeleteRom:
+		if !utils.ConfirmAction(fmt.Sprintf("Delete %s?", game.DisplayName)) {
+			return sds.Usage, 4, nil
+		}
+
+		utils.DeleteRom(game, romDirectory, collectionMap)
+	}
+
+	utils.RefreshStorageReport()
+
+	usage := sds.Usage
+	usage.Size -= selected.Size
+	usage.FileCount--
+	usage.Largest = slices.DeleteFunc(slices.Clone(usage.Largest), func(item models.StorageItem) bool {
+		return item.Path == selected.Path
+	})
+
+	return usage, 4, nil
+}
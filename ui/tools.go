@@ -28,6 +28,27 @@ func (ts ToolsScreen) Draw() (value interface{}, exitCode int, e error) {
 		Metadata: "Global Actions",
 	})
 
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Backlog",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Backlog",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Smart Collections",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Smart Collections",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Import Collection",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Import Collection",
+	})
+
 	menuItems = append(menuItems, gabagool.MenuItem{
 		Text:     "Play History",
 		Selected: false,
@@ -35,6 +56,90 @@ func (ts ToolsScreen) Draw() (value interface{}, exitCode int, e error) {
 		Metadata: "Play History",
 	})
 
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Play Statistics",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Play Statistics",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Year in Review",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Year in Review",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Orphaned Play History",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Orphaned Play History",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Export Play History",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Export Play History",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Import Play History",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Import Play History",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Undo Last Operation",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Undo Last Operation",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Operation History",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Operation History",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Empty Trash",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Empty Trash",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Validate Collections",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Validate Collections",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Verify ROMs",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Verify ROMs",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Storage Usage",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Storage Usage",
+	})
+
+	menuItems = append(menuItems, gabagool.MenuItem{
+		Text:     "Orphaned Files",
+		Selected: false,
+		Focused:  false,
+		Metadata: "Orphaned Files",
+	})
+
 	options := gabagool.DefaultListOptions("Tools", menuItems)
 
 	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
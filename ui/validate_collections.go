@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/veandco/go-sdl2/sdl"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"path/filepath"
+	"qlova.tech/sum"
+	"strings"
+	"time"
+)
+
+const (
+	fixCollectionEntries   = "Fix Selected"
+	pruneCollectionEntries = "Prune Selected"
+)
+
+type ValidateCollectionsScreen struct{}
+
+func InitValidateCollectionsScreen() ValidateCollectionsScreen {
+	return ValidateCollectionsScreen{}
+}
+
+func (vcs ValidateCollectionsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ValidateCollections
+}
+
+// Scans collections for entries that no longer point at a ROM and fixes or prunes the selected ones
+func (vcs ValidateCollectionsScreen) Draw() (item interface{}, exitCode int, e error) {
+	var missing []models.MissingCollectionEntry
+	var scanErr error
+
+	gaba.ProcessMessage("Validating collections...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		missing, scanErr = utils.ValidateCollections()
+		return nil, nil
+	})
+
+	if scanErr != nil {
+		utils.ShowTimedMessage("Unable to validate collections!", time.Second*2)
+		return nil, -1, scanErr
+	}
+
+	if len(missing) == 0 {
+		utils.ShowTimedMessage("All collection entries are valid!", time.Second*2)
+		return nil, 0, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, entry := range missing {
+		resolution := "Not Found"
+		if entry.Resolvable() {
+			resolution = entry.Resolution
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s : %s (%s)", entry.Collection.DisplayName, strings.TrimSuffix(filepath.Base(entry.Entry), filepath.Ext(entry.Entry)), resolution),
+			Selected: true,
+			Focused:  false,
+			Metadata: entry,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%d Missing Collection Entries", len(missing)), menuItems)
+	options.SmallTitle = true
+	options.EnableMultiSelect = true
+	options.StartInMultiSelectMode = true
+	options.MultiSelectButton = gaba.ButtonUnassigned
+	options.MultiSelectKey = sdl.K_0
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select / Unselect"},
+		{ButtonName: "Start", HelpText: "Confirm"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 || len(selection.Unwrap().SelectedItems) == 0 {
+		return nil, 2, nil
+	}
+
+	var selected []models.MissingCollectionEntry
+	for _, menuItem := range selection.Unwrap().SelectedItems {
+		selected = append(selected, menuItem.Metadata.(models.MissingCollectionEntry))
+	}
+
+	actionOptions := gaba.DefaultListOptions(fmt.Sprintf("%d Selected Entries", len(selected)), []gaba.MenuItem{
+		{Text: fixCollectionEntries, Metadata: fixCollectionEntries},
+		{Text: pruneCollectionEntries, Metadata: pruneCollectionEntries},
+	})
+	actionOptions.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	action, err := gaba.List(actionOptions)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !action.IsSome() || action.Unwrap().SelectedIndex == -1 {
+		return nil, 4, nil
+	}
+
+	collectionMap := state.GetCollectionMap()
+	defer state.ClearCollectionMap()
+
+	switch action.Unwrap().SelectedItem.Metadata {
+	case fixCollectionEntries:
+		var fixed int
+		var fixErr error
+		gaba.ProcessMessage("Fixing collection entries...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+			fixed, fixErr = utils.FixCollectionEntries(selected, collectionMap)
+			return nil, nil
+		})
+
+		if fixErr != nil {
+			utils.ShowTimedMessage("Some entries could not be fixed!", time.Second*2)
+		} else {
+			utils.ShowTimedMessage(fmt.Sprintf("Fixed %d/%d collection entries!", fixed, len(selected)), time.Second*2)
+		}
+	case pruneCollectionEntries:
+		if !utils.ConfirmAction(fmt.Sprintf("Remove %d entries from your collections?", len(selected))) {
+			return nil, 4, nil
+		}
+
+		if err := utils.PruneCollectionEntries(selected); err != nil {
+			utils.ShowTimedMessage("Some entries could not be pruned!", time.Second*2)
+		} else {
+			utils.ShowTimedMessage(fmt.Sprintf("Pruned %d collection entries!", len(selected)), time.Second*2)
+		}
+	}
+
+	return nil, 4, nil
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"time"
+)
+
+type VerifyRomsScreen struct{}
+
+func InitVerifyRomsScreen() VerifyRomsScreen {
+	return VerifyRomsScreen{}
+}
+
+func (vrs VerifyRomsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.VerifyRoms
+}
+
+// Lists the platforms that have a DAT installed
+func (vrs VerifyRomsScreen) Draw() (item interface{}, exitCode int, e error) {
+	romDirectories, err := utils.GetRomDirectories()
+	if err != nil {
+		utils.ShowTimedMessage("Unable to load ROM directories!", time.Second*2)
+		return nil, -1, err
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, romDirectory := range romDirectories {
+		if !utils.HasDat(romDirectory.Tag) {
+			continue
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     romDirectory.DisplayName,
+			Selected: false,
+			Focused:  false,
+			Metadata: romDirectory,
+		})
+	}
+
+	if len(menuItems) == 0 {
+		utils.ShowTimedMessage(fmt.Sprintf("No DAT files found!\nAdd them to %s", utils.GetDatDirectory()), time.Second*3)
+		return nil, 404, nil
+	}
+
+	options := gaba.DefaultListOptions("Verify ROMs", menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Verify"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(shared.RomDirectory), 0, nil
+	}
+
+	return nil, 2, nil
+}
+
+type VerificationReportScreen struct {
+	RomDirectory shared.RomDirectory
+}
+
+func InitVerificationReportScreen(romDirectory shared.RomDirectory) VerificationReportScreen {
+	return VerificationReportScreen{
+		RomDirectory: romDirectory,
+	}
+}
+
+func (vrs VerificationReportScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.VerificationReport
+}
+
+// Hashes the platform, shows each ROM's DAT status and renames misnamed ROMs to their canonical name
+func (vrs VerificationReportScreen) Draw() (item interface{}, exitCode int, e error) {
+	var results []models.VerificationResult
+	var verifyErr error
+
+	gaba.ProcessMessage(fmt.Sprintf("Verifying %s...", vrs.RomDirectory.DisplayName), gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		results, verifyErr = utils.VerifyRomDirectory(vrs.RomDirectory)
+		return nil, nil
+	})
+
+	if verifyErr != nil {
+		utils.ShowTimedMessage("Unable to verify ROMs!", time.Second*2)
+		return nil, -1, verifyErr
+	}
+
+	if len(results) == 0 {
+		utils.ShowTimedMessage("No ROMs found!", time.Second*2)
+		return nil, 404, nil
+	}
+
+	counts := make(map[string]int)
+	var menuItems []gaba.MenuItem
+	for _, result := range results {
+		counts[result.Status]++
+
+		text := fmt.Sprintf("[%s] %s", result.Status, result.Game.Filename)
+		if result.Status == models.VerificationMisnamed {
+			text = fmt.Sprintf("[%s] %s -> %s", result.Status, result.Game.Filename, result.CanonicalName)
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:               text,
+			Selected:           false,
+			Focused:            false,
+			Metadata:           result,
+			NotMultiSelectable: result.Status != models.VerificationMisnamed,
+		})
+	}
+
+	title := fmt.Sprintf("%d Verified | %d Bad | %d Unknown | %d Misnamed",
+		counts[models.VerificationVerified], counts[models.VerificationBadDump],
+		counts[models.VerificationUnknown], counts[models.VerificationMisnamed])
+
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.SmallTitle = true
+	options.EnableMultiSelect = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "Menu", HelpText: "Help"},
+		{ButtonName: "A", HelpText: "Rename"},
+	}
+
+	options.EnableHelp = true
+	options.HelpTitle = "Verification Report"
+	options.HelpText = []string{
+		"• A: Rename a misnamed ROM to its DAT name",
+		"• Select: Toggle Multi-Select",
+		"• Start: Confirm Multi-Selection",
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	var misnamed []models.VerificationResult
+	for _, selected := range selection.Unwrap().SelectedItems {
+		result := selected.Metadata.(models.VerificationResult)
+		if result.Status == models.VerificationMisnamed {
+			misnamed = append(misnamed, result)
+		}
+	}
+
+	if len(misnamed) == 0 {
+		utils.ShowTimedMessage("Only misnamed ROMs can be renamed!", time.Second*2)
+		return nil, 4, nil
+	}
+
+	message := fmt.Sprintf("Rename %s to %s?", misnamed[0].Game.Filename, misnamed[0].CanonicalName)
+	if len(misnamed) > 1 {
+		message = fmt.Sprintf("Rename %d ROMs to their DAT names?", len(misnamed))
+	}
+
+	if !utils.ConfirmAction(message) {
+		return nil, 4, nil
+	}
+
+	collectionMap := state.GetCollectionMap()
+	defer state.ClearCollectionMap()
+
+	utils.BeginOperation(fmt.Sprintf("Rename %d ROMs to DAT Names", len(misnamed)))
+	renamed := 0
+	for _, result := range misnamed {
+		if _, err := utils.RenameToCanonical(result, collectionMap); err == nil {
+			renamed++
+		}
+	}
+	utils.CommitOperation()
+
+	utils.ShowTimedMessage(fmt.Sprintf("Renamed %d/%d ROMs!", renamed, len(misnamed)), time.Second*2)
+	return nil, 4, nil
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"nextui-game-manager/state"
+	"nextui-game-manager/utils"
+	"qlova.tech/sum"
+	"strconv"
+	"time"
+)
+
+type YearInReviewScreen struct{}
+
+func InitYearInReviewScreen() YearInReviewScreen {
+	return YearInReviewScreen{}
+}
+
+func (yirs YearInReviewScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.YearInReview
+}
+
+// Lists the years with play history to build a review for
+func (yirs YearInReviewScreen) Draw() (item interface{}, exitCode int, e error) {
+	years, err := utils.AvailableReviewYears()
+	if err != nil {
+		utils.ShowTimedMessage("Unable to read play history!", time.Second*2)
+		return nil, -1, err
+	}
+
+	if len(years) == 0 {
+		utils.ShowTimedMessage("No play history yet!", time.Second*2)
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, year := range years {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     strconv.Itoa(year),
+			Selected: false,
+			Focused:  false,
+			Metadata: year,
+		})
+	}
+
+	options := gaba.DefaultListOptions("Year in Review", menuItems)
+
+	selectedIndex, visibleStartIndex := state.GetCurrentMenuPosition()
+	options.SelectedIndex = selectedIndex
+	options.VisibleStartIndex = visibleStartIndex
+
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Review"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		state.UpdateCurrentMenuPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		return selection.Unwrap().SelectedItem.Metadata.(int), 0, nil
+	}
+
+	return nil, 2, nil
+}
+
+type YearInReviewReportScreen struct {
+	Year int
+}
+
+func InitYearInReviewReportScreen(year int) YearInReviewReportScreen {
+	return YearInReviewReportScreen{
+		Year: year,
+	}
+}
+
+func (yirrs YearInReviewReportScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.YearInReviewReport
+}
+
+// Pages through the rendered review with A, the last page offers to export it to the SD card
+func (yirrs YearInReviewReportScreen) Draw() (item interface{}, exitCode int, e error) {
+	logger := common.GetLoggerInstance()
+
+	var review models.YearInReview
+	var pages []string
+	var err error
+	gaba.ProcessMessage(fmt.Sprintf("Reviewing %d...", yirrs.Year), gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		review, err = utils.GenerateYearInReview(yirrs.Year)
+		if err == nil {
+			pages, err = utils.RenderYearInReviewPages(review)
+		}
+		return nil, nil
+	})
+
+	if err != nil {
+		logger.Error("Unable to build year in review", zap.Int("year", yirrs.Year), zap.Error(err))
+		utils.ShowTimedMessage("Unable to build year in review!", time.Second*2)
+		return nil, -1, err
+	}
+
+	for i, page := range pages {
+		confirm := "Next"
+		if i == len(pages)-1 {
+			confirm = "Export"
+		}
+
+		result, err := gaba.ConfirmationMessage("", []gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Back"},
+			{ButtonName: "A", HelpText: confirm},
+		}, gaba.MessageOptions{
+			ImagePath: page,
+		})
+		if err != nil {
+			return nil, -1, err
+		}
+
+		if result.IsNone() {
+			return nil, 2, nil
+		}
+	}
+
+	var written []string
+	gaba.ProcessMessage("Exporting year in review...", gaba.ProcessMessageOptions{}, func() (interface{}, error) {
+		written, err = utils.ExportYearInReview(review, pages, utils.GetExportDirectory())
+		return nil, nil
+	})
+
+	if err != nil {
+		logger.Error("Unable to export year in review", zap.Int("year", yirrs.Year), zap.Error(err))
+		utils.ShowTimedMessage("Unable to export year in review!", time.Second*2)
+		return nil, -1, err
+	}
+
+	utils.ShowTimedMessage(fmt.Sprintf("Exported %d files to %s", len(written), utils.GetExportDirectory()), time.Second*3)
+	return nil, 0, nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
 	"go.uber.org/zap"
+	"nextui-game-manager/models"
 	"os"
 	"path/filepath"
 	"strings"
@@ -44,7 +45,7 @@ func GetArchiveFileList() ([]string, error) {
 	return archiveFolders, nil
 }
 
-func ArchiveRom(selectedGame shared.Item, romDirectory shared.RomDirectory, archiveName string) error {
+func ArchiveRom(selectedGame shared.Item, romDirectory shared.RomDirectory, archiveName string, collectionMap map[string][]models.Collection) error {
 	logger := common.GetLoggerInstance()
 
 	sourcePath := filepath.Join(romDirectory.Path, selectedGame.Filename)
@@ -52,15 +53,25 @@ func ArchiveRom(selectedGame shared.Item, romDirectory shared.RomDirectory, arch
 
 	logger.Debug("Archiving ROM", zap.String("from", sourcePath), zap.String("to", destinationPath))
 
+	BeginOperation(fmt.Sprintf("Archive %s", selectedGame.DisplayName))
+	defer CommitOperation()
+
+	companions := romCompanions(sourcePath)
 	if err := MoveFile(sourcePath, destinationPath); err != nil {
 		return fmt.Errorf("failed to archive ROM: %w", err)
 	}
+	moveRomCompanions(companions, filepath.Dir(destinationPath), logger)
 
+	removeArchivedRomFromCollections(collectionMap, collectionEntryForRom(selectedGame, sourcePath), destinationPath)
 	archiveArtFile(selectedGame.Filename, romDirectory, archiveName, logger)
+	archiveSaveFiles(selectedGame, romDirectory, archiveName)
+	archiveSaveStates(selectedGame, romDirectory, archiveName)
+	moveGameTrackerPaths(buildGameTrackerPath(romDirectory.Path, selectedGame.Filename),
+		buildGameTrackerPath(filepath.Dir(destinationPath), selectedGame.Filename))
 	return nil
 }
 
-func RestoreRom(selectedGame shared.Item, romDirectory shared.RomDirectory, archive shared.RomDirectory) error {
+func RestoreRom(selectedGame shared.Item, romDirectory shared.RomDirectory, archive shared.RomDirectory, collectionMap map[string][]models.Collection) error {
 	logger := common.GetLoggerInstance()
 
 	sourcePath := filepath.Join(romDirectory.Path, selectedGame.Filename)
@@ -68,11 +79,21 @@ func RestoreRom(selectedGame shared.Item, romDirectory shared.RomDirectory, arch
 
 	logger.Debug("Restoring ROM", zap.String("from", sourcePath), zap.String("to", destinationPath))
 
+	BeginOperation(fmt.Sprintf("Restore %s", selectedGame.DisplayName))
+	defer CommitOperation()
+
+	companions := romCompanions(sourcePath)
 	if err := MoveFile(sourcePath, destinationPath); err != nil {
 		return fmt.Errorf("failed to restore ROM: %w", err)
 	}
+	moveRomCompanions(companions, filepath.Dir(destinationPath), logger)
 
+	restoreArchivedRomToCollections(sourcePath, collectionEntryForRom(selectedGame, destinationPath))
 	restoreArtFile(selectedGame.Filename, romDirectory, archive, logger)
+	restoreSaveFiles(selectedGame, romDirectory, archive)
+	restoreSaveStates(selectedGame, romDirectory, archive)
+	moveGameTrackerPaths(buildGameTrackerPath(romDirectory.Path, selectedGame.Filename),
+		buildGameTrackerPath(filepath.Dir(destinationPath), selectedGame.Filename))
 	return nil
 }
 
@@ -152,6 +173,15 @@ func buildRestorePath(filename string, romDirectory shared.RomDirectory, archive
 	return filepath.Join(GetRomDirectory(), subdirectory, filename)
 }
 
+// moveRomCompanions moves the tracks and discs of a cue sheet or .m3u along with it.
+func moveRomCompanions(companions []string, destinationDirectory string, logger *zap.Logger) {
+	for _, companion := range companions {
+		if err := MoveFile(companion, filepath.Join(destinationDirectory, filepath.Base(companion))); err != nil {
+			logger.Error("Failed to move ROM companion", zap.String("file", companion), zap.Error(err))
+		}
+	}
+}
+
 func archiveArtFile(filename string, romDirectory shared.RomDirectory, archiveName string, logger *zap.Logger) {
 	artPath, err := FindExistingArt(filename, romDirectory)
 	if err != nil || artPath == "" {
@@ -180,3 +210,26 @@ func restoreArtFile(filename string, romDirectory shared.RomDirectory, archive s
 		logger.Error("Failed to restore art file", zap.Error(err))
 	}
 }
+
+// ArchiveFromPath returns the archive folder that contains the given path inside the ROM directory.
+func ArchiveFromPath(path string) (shared.RomDirectory, error) {
+	relativePath, err := filepath.Rel(GetRomDirectory(), path)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return shared.RomDirectory{}, fmt.Errorf("%s is not inside the ROM directory", path)
+	}
+
+	archiveName := strings.Split(relativePath, string(filepath.Separator))[0]
+	if !strings.HasPrefix(archiveName, ".") || archiveName == ".media" {
+		return shared.RomDirectory{}, fmt.Errorf("%s is not inside an archive folder", path)
+	}
+
+	return shared.RomDirectory{
+		DisplayName: archiveName,
+		Path:        GetArchiveRoot(archiveName),
+	}, nil
+}
+
+func isArchivedPath(path string) bool {
+	_, err := ArchiveFromPath(path)
+	return err == nil
+}
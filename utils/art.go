@@ -56,12 +56,14 @@ func FindAllArt(romDirectory shared.RomDirectory, games shared.Items, downloadTy
 	}
 
 	for _, game := range games {
-		matchedArt := findMatchingArt(artList, game.Filename, fuzzySearchThreshold)
+		matchedArt := findMatchingArt(artList, game, romDirectory, fuzzySearchThreshold)
 		if matchedArt.Filename != "" {
 			artMap[game] = matchedArt.Filename
 		}
 	}
 
+	SaveHashCache()
+
 	downloads := buildArtDownloads(artMap, client.RootURL, section)
 
 	return downloads
@@ -80,7 +82,8 @@ func FindArt(romDirectory shared.RomDirectory, game shared.Item, downloadType su
 		return ""
 	}
 
-	matchedArt := findMatchingArt(artList, game.Filename, fuzzySearchThreshold)
+	matchedArt := findMatchingArt(artList, game, romDirectory, fuzzySearchThreshold)
+	SaveHashCache()
 	if matchedArt.Filename == "" {
 		return ""
 	}
@@ -160,9 +163,9 @@ func findRomsWithoutArtInDirectory(romDir shared.RomDirectory) ([]shared.Item, e
 	return romsWithoutArt, nil
 }
 
-func findMatchingArt(artList []shared.Item, filename string, fuzzySearchThreshold float64) shared.Item {
+func findMatchingArt(artList []shared.Item, game shared.Item, romDirectory shared.RomDirectory, fuzzySearchThreshold float64) shared.Item {
 	// toastd's trick for Libretro Thumbnail Naming
-	cleanedName := strings.ReplaceAll(filename, "&", "_")
+	cleanedName := strings.ReplaceAll(game.Filename, "&", "_")
 
 	targetName := removeFileExtension(cleanedName)
 
@@ -171,12 +174,20 @@ func findMatchingArt(artList []shared.Item, filename string, fuzzySearchThreshol
 	})
 
 	// naive search first
-	if idx, found := slices.BinarySearchFunc(artList, targetName, func(item shared.Item, tg string) int {
-		stripExt := strings.TrimSuffix(item.Filename, filepath.Ext(item.Filename))
-		return strings.Compare(strings.ToLower(stripExt), strings.ToLower(tg))
-	}); found {
+	if idx, found := searchArtList(artList, targetName); found {
 		return artList[idx]
-	} else if fuzzyMatch, meetsThreshold := fuzzyArtSearch(targetName, artList, fuzzySearchThreshold); meetsThreshold {
+	}
+
+	// the DAT name is what the thumbnail project uses, so when the ROM's hash is known it beats a fuzzy match
+	if !game.IsDirectory && HasDat(romDirectory.Tag) {
+		if canonicalName, found := FindCanonicalName(game.Path, romDirectory.Tag); found {
+			if idx, found := searchArtList(artList, strings.ReplaceAll(canonicalName, "&", "_")); found {
+				return artList[idx]
+			}
+		}
+	}
+
+	if fuzzyMatch, meetsThreshold := fuzzyArtSearch(targetName, artList, fuzzySearchThreshold); meetsThreshold {
 		return shared.Item{
 			Filename: fuzzyMatch,
 		}
@@ -185,81 +196,24 @@ func findMatchingArt(artList []shared.Item, filename string, fuzzySearchThreshol
 	return shared.Item{}
 }
 
+func searchArtList(artList []shared.Item, targetName string) (int, bool) {
+	return slices.BinarySearchFunc(artList, targetName, func(item shared.Item, tg string) int {
+		stripExt := strings.TrimSuffix(item.Filename, filepath.Ext(item.Filename))
+		return strings.Compare(strings.ToLower(stripExt), strings.ToLower(tg))
+	})
+}
+
 func fuzzyArtSearch(romFilename string, artList []shared.Item, threshold float64) (string, bool) {
 	if threshold > .85 || threshold < .5 {
 		threshold = .8 // Default
 	}
 
-	// Calculate similarity between two strings using Jaccard similarity
-	similarity := func(s1, s2 string) float64 {
-		// Tokenize
-		tokens1 := make(map[string]bool)
-		tokens2 := make(map[string]bool)
-
-		for _, token := range strings.Fields(s1) {
-			tokens1[token] = true
-		}
-		for _, token := range strings.Fields(s2) {
-			tokens2[token] = true
-		}
-
-		// Calculate intersection and union
-		intersection := 0
-		for token := range tokens1 {
-			if tokens2[token] {
-				intersection++
-			}
-		}
-
-		union := len(tokens1) + len(tokens2) - intersection
-		if union == 0 {
-			return 0
-		}
-
-		// Also consider character-level similarity for the main title
-		title1 := strings.Split(s1, "(")[0]
-		title2 := strings.Split(s2, "(")[0]
-
-		// Simple character overlap ratio
-		charSim := 0.0
-		if len(title1) > 0 && len(title2) > 0 {
-			matches := 0
-			maxLen := len(title1)
-			if len(title2) > maxLen {
-				maxLen = len(title2)
-			}
-
-			// Count matching characters in order
-			j := 0
-			for i := 0; i < len(title1) && j < len(title2); i++ {
-				if title1[i] == title2[j] {
-					matches++
-					j++
-				} else {
-					// Look ahead for match
-					for k := j + 1; k < len(title2) && k-j < 3; k++ {
-						if title1[i] == title2[k] {
-							j = k + 1
-							matches++
-							break
-						}
-					}
-				}
-			}
-			charSim = float64(matches) / float64(maxLen)
-		}
-
-		// Weighted combination of token and character similarity
-		tokenSim := float64(intersection) / float64(union)
-		return tokenSim*0.6 + charSim*0.4
-	}
-
 	bestMatch := ""
 	bestScore := 0.0
 
 	for _, art := range artList {
 		pngNorm := removeFileExtension(art.Filename)
-		score := similarity(romFilename, pngNorm)
+		score := titleSimilarity(romFilename, pngNorm)
 
 		zipRegions := regexp.MustCompile(`\((.*?)\)`).FindAllStringSubmatch(romFilename, -1)
 		pngRegions := regexp.MustCompile(`\((.*?)\)`).FindAllStringSubmatch(art.Filename, -1)
@@ -296,6 +250,70 @@ func fuzzyArtSearch(romFilename string, artList []shared.Item, threshold float64
 	return bestMatch, reachedThreshold
 }
 
+// titleSimilarity calculates similarity between two strings using Jaccard similarity
+func titleSimilarity(s1, s2 string) float64 {
+	// Tokenize
+	tokens1 := make(map[string]bool)
+	tokens2 := make(map[string]bool)
+
+	for _, token := range strings.Fields(s1) {
+		tokens1[token] = true
+	}
+	for _, token := range strings.Fields(s2) {
+		tokens2[token] = true
+	}
+
+	// Calculate intersection and union
+	intersection := 0
+	for token := range tokens1 {
+		if tokens2[token] {
+			intersection++
+		}
+	}
+
+	union := len(tokens1) + len(tokens2) - intersection
+	if union == 0 {
+		return 0
+	}
+
+	// Also consider character-level similarity for the main title
+	title1 := strings.Split(s1, "(")[0]
+	title2 := strings.Split(s2, "(")[0]
+
+	// Simple character overlap ratio
+	charSim := 0.0
+	if len(title1) > 0 && len(title2) > 0 {
+		matches := 0
+		maxLen := len(title1)
+		if len(title2) > maxLen {
+			maxLen = len(title2)
+		}
+
+		// Count matching characters in order
+		j := 0
+		for i := 0; i < len(title1) && j < len(title2); i++ {
+			if title1[i] == title2[j] {
+				matches++
+				j++
+			} else {
+				// Look ahead for match
+				for k := j + 1; k < len(title2) && k-j < 3; k++ {
+					if title1[i] == title2[k] {
+						j = k + 1
+						matches++
+						break
+					}
+				}
+			}
+		}
+		charSim = float64(matches) / float64(maxLen)
+	}
+
+	// Weighted combination of token and character similarity
+	tokenSim := float64(intersection) / float64(union)
+	return tokenSim*0.6 + charSim*0.4
+}
+
 func buildArtDownloads(artMap map[shared.Item]string, rootUrl string, section shared.Section) []gaba.Download {
 	var downloads []gaba.Download
 
@@ -367,5 +385,10 @@ func DeleteArt(filename string, romDirectory shared.RomDirectory) {
 		return
 	}
 
-	common.DeleteFile(artPath)
+	BeginOperation(fmt.Sprintf("Delete art for %s", removeFileExtension(filename)))
+	defer CommitOperation()
+
+	if err := TrashFile(artPath); err != nil {
+		logger.Error("Failed to delete art", zap.Error(err))
+	}
 }
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"maps"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	autoCollectionsFilename = "auto_collections.json"
+
+	autoMostPlayedCount = 25
+	autoRecentDays      = 30
+	autoFavoritesCount  = 10
+)
+
+type autoCollection struct {
+	name  string
+	games []models.PlayHistoryAggregate
+	limit int
+}
+
+// RefreshAutoCollections rebuilds the play history collections, returning how many collections were written.
+// Most Played and per platform Favorites are ordered by play time, Recently Played by when the game was last played.
+// Collections left without a game on the SD card are not written, and ones written before that no longer are get removed.
+// A collection the user already has under the same name is left alone.
+func RefreshAutoCollections(now time.Time) (int, error) {
+	gamePlayMap, _, _, err := GenerateCurrentGameStats(models.PlayHistoryQuery{})
+	if err != nil {
+		return 0, err
+	}
+
+	recentPlayMap, _, _, err := GenerateCurrentGameStats(models.PlayHistoryQuery{Start: now.AddDate(0, 0, -autoRecentDays)})
+	if err != nil {
+		return 0, err
+	}
+
+	var allGames []models.PlayHistoryAggregate
+	for _, games := range gamePlayMap {
+		allGames = append(allGames, games...)
+	}
+	slices.SortStableFunc(allGames, func(a, b models.PlayHistoryAggregate) int {
+		return b.PlayTimeTotal - a.PlayTimeTotal
+	})
+
+	var recentGames []models.PlayHistoryAggregate
+	for _, games := range recentPlayMap {
+		recentGames = append(recentGames, games...)
+	}
+	slices.SortStableFunc(recentGames, func(a, b models.PlayHistoryAggregate) int {
+		return b.LastPlayedTime.Compare(a.LastPlayedTime)
+	})
+
+	collections := []autoCollection{
+		{name: fmt.Sprintf("Top %d Most Played", autoMostPlayedCount), games: allGames, limit: autoMostPlayedCount},
+		{name: fmt.Sprintf("Played in the Last %d Days", autoRecentDays), games: recentGames, limit: len(recentGames)},
+	}
+
+	for _, console := range slices.Sorted(maps.Keys(gamePlayMap)) {
+		collections = append(collections, autoCollection{name: platformDisplayName(console) + " Favorites", games: gamePlayMap[console], limit: autoFavoritesCount})
+	}
+
+	logger := common.GetLoggerInstance()
+	generated := loadGeneratedCollections(autoCollectionsFilename)
+
+	var written []string
+	for _, collection := range collections {
+		if isUserCollection(collection.name, generated) {
+			logger.Info("Skipping auto collection, a collection with that name already exists", zap.String("collection", collection.name))
+			continue
+		}
+
+		saved, err := saveAutoCollection(collection)
+		if err != nil {
+			removeStaleAutoCollections(generated, written)
+			return len(written), err
+		}
+		if saved {
+			written = append(written, collection.name)
+		}
+	}
+
+	removeStaleAutoCollections(generated, written)
+	return len(written), nil
+}
+
+// RemoveAutoCollections deletes every play history collection, for when they are turned off in Settings.
+func RemoveAutoCollections() {
+	removeStaleAutoCollections(loadGeneratedCollections(autoCollectionsFilename), nil)
+}
+
+// removeStaleAutoCollections deletes the collections an earlier refresh generated that are not in written and
+// remembers written for the next refresh.
+func removeStaleAutoCollections(generated []string, written []string) {
+	for _, name := range generated {
+		if slices.Contains(written, name) {
+			continue
+		}
+
+		collectionFile := filepath.Join(GetCollectionDirectory(), name+".txt")
+		if err := os.Remove(collectionFile); err != nil && !os.IsNotExist(err) {
+			common.GetLoggerInstance().Error("Failed to remove auto collection", zap.String("collection", name), zap.Error(err))
+		}
+	}
+
+	saveGeneratedCollections(autoCollectionsFilename, written)
+}
+
+// saveAutoCollection writes the first limit games that are still on the SD card and outside the archives.
+// A collection without any such game is not written.
+func saveAutoCollection(autoCollection autoCollection) (bool, error) {
+	collection := models.Collection{
+		DisplayName:    autoCollection.name,
+		CollectionFile: filepath.Join(GetCollectionDirectory(), autoCollection.name+".txt"),
+	}
+
+	for _, game := range autoCollection.games {
+		if len(collection.Games) >= autoCollection.limit {
+			break
+		}
+
+		if item, found := playHistoryCollectionItem(game); found {
+			collection.Games = append(collection.Games, item)
+		}
+	}
+
+	if len(collection.Games) == 0 {
+		return false, nil
+	}
+
+	if err := SaveCollection(collection); err != nil {
+		common.GetLoggerInstance().Error("Failed to save auto collection", zap.String("collection", autoCollection.name), zap.Error(err))
+		return false, err
+	}
+
+	return true, nil
+}
+
+// playHistoryCollectionItem builds the collection item of a played game, multi-disc games are written as their .m3u.
+func playHistoryCollectionItem(game models.PlayHistoryAggregate) (shared.Item, bool) {
+	info, err := os.Stat(game.Path)
+	if err != nil || isArchivedPath(game.Path) {
+		return shared.Item{}, false
+	}
+
+	item := shared.Item{
+		DisplayName: game.Name,
+		Filename:    filepath.Base(game.Path),
+		Path:        game.Path,
+	}
+
+	if info.IsDir() {
+		item.DisplayName = filepath.Base(game.Path)
+		item.IsDirectory = true
+		item.IsMultiDiscDirectory = true
+	}
+
+	return item, true
+}
@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// backlogFile lives next to config.yml so it travels with the pak's settings.
+const backlogFile = "backlog.yml"
+
+func LoadBacklog() (models.Backlog, error) {
+	var backlog models.Backlog
+
+	data, err := os.ReadFile(backlogFile)
+	if os.IsNotExist(err) {
+		return backlog, nil
+	} else if err != nil {
+		return backlog, fmt.Errorf("reading %s: %w", backlogFile, err)
+	}
+
+	if err := yaml.Unmarshal(data, &backlog); err != nil {
+		return backlog, fmt.Errorf("parsing %s: %w", backlogFile, err)
+	}
+
+	return backlog, nil
+}
+
+func saveBacklog(backlog models.Backlog) error {
+	data, err := yaml.Marshal(backlog)
+	if err != nil {
+		return fmt.Errorf("failed to encode backlog: %w", err)
+	}
+
+	if absolutePath, err := filepath.Abs(backlogFile); err == nil {
+		journalFileWrite(absolutePath)
+	}
+
+	if err := os.WriteFile(backlogFile+".tmp", data, defaultFilePerm); err != nil {
+		return fmt.Errorf("failed to write backlog: %w", err)
+	}
+
+	return os.Rename(backlogFile+".tmp", backlogFile)
+}
+
+// FindBacklogEntry returns the backlog entry of a game, if it has one.
+func FindBacklogEntry(game shared.Item) (models.BacklogEntry, bool) {
+	backlog, err := LoadBacklog()
+	if err != nil {
+		return models.BacklogEntry{}, false
+	}
+
+	path := trackerPathForRom(game.Path)
+	index := slices.IndexFunc(backlog.Games, func(entry models.BacklogEntry) bool {
+		return entry.Path == path
+	})
+	if index == -1 {
+		return models.BacklogEntry{}, false
+	}
+
+	return backlog.Games[index], true
+}
+
+// SetBacklogStatus adds or updates the game's backlog entry, an empty status takes the game off the backlog.
+func SetBacklogStatus(game shared.Item, status string, targetHours float64) error {
+	backlog, err := LoadBacklog()
+	if err != nil {
+		return err
+	}
+
+	path := trackerPathForRom(game.Path)
+	backlog.Games = slices.DeleteFunc(backlog.Games, func(entry models.BacklogEntry) bool {
+		return entry.Path == path
+	})
+
+	if status != "" {
+		backlog.Games = append(backlog.Games, models.BacklogEntry{
+			Path:        path,
+			Name:        game.DisplayName,
+			Status:      status,
+			TargetHours: targetHours,
+			UpdatedAt:   time.Now(),
+		})
+	}
+
+	return saveBacklog(backlog)
+}
+
+// resolveBacklogRomPath finds the ROM of a backlog entry in the Roms folder or, once archived, in one of the archives.
+func resolveBacklogRomPath(entryPath string) (string, bool) {
+	romPath := filepath.Join(GetRomDirectory(), entryPath)
+	if DoesFileExists(romPath) {
+		return romPath, true
+	}
+
+	archives, err := GetArchiveFileListBasic()
+	if err != nil {
+		return romPath, false
+	}
+
+	for _, archive := range archives {
+		if archivedPath := filepath.Join(GetArchiveRoot(archive), entryPath); DoesFileExists(archivedPath) {
+			return archivedPath, true
+		}
+	}
+
+	return romPath, false
+}
+
+// removeBacklogEntry takes a deleted ROM off the backlog, undoing the deletion brings the entry back.
+func removeBacklogEntry(romPath string) {
+	backlog, err := LoadBacklog()
+	if err != nil {
+		return
+	}
+
+	path := trackerPathForRom(romPath)
+	games := slices.DeleteFunc(slices.Clone(backlog.Games), func(entry models.BacklogEntry) bool {
+		return entry.Path == path
+	})
+	if len(games) == len(backlog.Games) {
+		return
+	}
+
+	backlog.Games = games
+	if err := saveBacklog(backlog); err != nil {
+		common.GetLoggerInstance().Error("Failed to update backlog", zap.Error(err))
+	}
+}
+
+// AddToCompletedCollection puts a finished game into the designated collection, creating the collection the first time.
+// Archived and deleted games are left out since NextUI could not launch them from the collection.
+func AddToCompletedCollection(game shared.Item, collectionName string, collectionMap map[string][]models.Collection) error {
+	if !DoesFileExists(game.Path) {
+		return fmt.Errorf("%s no longer exists", game.Path)
+	}
+	if isArchivedPath(game.Path) {
+		return fmt.Errorf("%s is archived", game.Path)
+	}
+
+	collection := models.Collection{
+		DisplayName:    collectionName,
+		CollectionFile: filepath.Join(GetCollectionDirectory(), collectionName+".txt"),
+	}
+
+	_, err := AddCollectionGames(collectionMap, collection, []shared.Item{game})
+	return err
+}
+
+// GetBacklogProgress lists the backlog by status with the tracked play time of each game.
+func GetBacklogProgress(gamePlayMap map[string][]models.PlayHistoryAggregate) ([]models.BacklogProgress, error) {
+	backlog, err := LoadBacklog()
+	if err != nil {
+		return nil, err
+	}
+
+	var progress []models.BacklogProgress
+	for _, entry := range backlog.Games {
+		romPath, found := resolveBacklogRomPath(entry.Path)
+		aggregate := CollectGameAggregateFromGamePath(romPath, extractPlayConsoleName(entry.Path), gamePlayMap)
+
+		progress = append(progress, models.BacklogProgress{
+			Entry:    entry,
+			RomPath:  romPath,
+			PlayTime: aggregate.PlayTimeTotal,
+			Missing:  !found,
+		})
+	}
+
+	slices.SortStableFunc(progress, func(a, b models.BacklogProgress) int {
+		aStatus := slices.Index(models.BacklogStatuses, a.Entry.Status)
+		bStatus := slices.Index(models.BacklogStatuses, b.Entry.Status)
+		if aStatus != bStatus {
+			return aStatus - bStatus
+		}
+		return strings.Compare(strings.ToLower(a.Entry.Name), strings.ToLower(b.Entry.Name))
+	})
+
+	return progress, nil
+}
+
+// renameBacklogEntry keeps a renamed ROM's backlog entry pointing at the new file.
+func renameBacklogEntry(oldRomPath string, newRomPath string, newName string) {
+	backlog, err := LoadBacklog()
+	if err != nil {
+		return
+	}
+
+	oldPath := trackerPathForRom(oldRomPath)
+	index := slices.IndexFunc(backlog.Games, func(entry models.BacklogEntry) bool {
+		return entry.Path == oldPath
+	})
+	if index == -1 {
+		return
+	}
+
+	backlog.Games[index].Path = trackerPathForRom(newRomPath)
+	backlog.Games[index].Name = newName
+
+	if err := saveBacklog(backlog); err != nil {
+		common.GetLoggerInstance().Error("Failed to update backlog", zap.Error(err))
+	}
+}
+
+// BacklogGameItem rebuilds the game item of a backlog entry for the screens and collections that expect one.
+func BacklogGameItem(progress models.BacklogProgress) shared.Item {
+	item := shared.Item{
+		DisplayName: progress.Entry.Name,
+		Filename:    filepath.Base(progress.RomPath),
+		Path:        progress.RomPath,
+	}
+
+	if info, err := os.Stat(progress.RomPath); err == nil && info.IsDir() {
+		item.IsDirectory = true
+		item.IsMultiDiscDirectory = true
+	}
+
+	return item
+}
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"fmt"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"nextui-game-manager/models"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DuplicateCollection copies a collection into a new one called name.
+func DuplicateCollection(collectionMap map[string][]models.Collection, source models.Collection, name string) (models.Collection, error) {
+	BeginOperation(fmt.Sprintf("Duplicate collection %s as %s", source.DisplayName, name))
+	defer CommitOperation()
+
+	source, err := ReadCollection(source)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	return createDerivedCollection(collectionMap, name, source.Games)
+}
+
+// MergeCollections creates a collection with the games of both collections, first's games come first.
+func MergeCollections(collectionMap map[string][]models.Collection, first models.Collection, second models.Collection, name string) (models.Collection, error) {
+	BeginOperation(fmt.Sprintf("Merge %s and %s into %s", first.DisplayName, second.DisplayName, name))
+	defer CommitOperation()
+
+	firstGames, secondGames, err := readCollectionPair(first, second)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	return createDerivedCollection(collectionMap, name, append(firstGames, secondGames...))
+}
+
+// IntersectCollections creates a collection with the games that are in both collections.
+func IntersectCollections(collectionMap map[string][]models.Collection, first models.Collection, second models.Collection, name string) (models.Collection, error) {
+	BeginOperation(fmt.Sprintf("Intersect %s and %s into %s", first.DisplayName, second.DisplayName, name))
+	defer CommitOperation()
+
+	firstGames, secondGames, err := readCollectionPair(first, second)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	games := slices.DeleteFunc(firstGames, func(game shared.Item) bool {
+		return !containsCollectionEntry(secondGames, game)
+	})
+
+	return createDerivedCollection(collectionMap, name, games)
+}
+
+// SubtractCollections creates a collection with the games of first that are not in second.
+func SubtractCollections(collectionMap map[string][]models.Collection, first models.Collection, second models.Collection, name string) (models.Collection, error) {
+	BeginOperation(fmt.Sprintf("Subtract %s from %s into %s", second.DisplayName, first.DisplayName, name))
+	defer CommitOperation()
+
+	firstGames, secondGames, err := readCollectionPair(first, second)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	games := slices.DeleteFunc(firstGames, func(game shared.Item) bool {
+		return containsCollectionEntry(secondGames, game)
+	})
+
+	return createDerivedCollection(collectionMap, name, games)
+}
+
+// SplitCollectionByPlatform adds the games of a collection to one "<collection> - <platform>" collection per platform.
+// Existing platform collections are added to rather than replaced.
+func SplitCollectionByPlatform(collectionMap map[string][]models.Collection, source models.Collection) ([]models.Collection, error) {
+	BeginOperation(fmt.Sprintf("Split collection %s by platform", source.DisplayName))
+	defer CommitOperation()
+
+	source, err := ReadCollection(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var platforms []string
+	gamesByPlatform := make(map[string][]shared.Item)
+	for _, game := range source.Games {
+		platform := strings.SplitN(strings.TrimPrefix(game.Path, "/Roms/"), "/", 2)[0]
+		if _, found := gamesByPlatform[platform]; !found {
+			platforms = append(platforms, platform)
+		}
+		gamesByPlatform[platform] = append(gamesByPlatform[platform], game)
+	}
+
+	var created []models.Collection
+	for _, platform := range platforms {
+		name := fmt.Sprintf("%s - %s", source.DisplayName, platformDisplayName(platform))
+		collection := models.Collection{
+			DisplayName:    name,
+			CollectionFile: filepath.Join(GetCollectionDirectory(), name+".txt"),
+		}
+
+		collection, err := AddCollectionGames(collectionMap, collection, gamesByPlatform[platform])
+		if err != nil {
+			return created, err
+		}
+		created = append(created, collection)
+	}
+
+	return created, nil
+}
+
+func readCollectionPair(first models.Collection, second models.Collection) ([]shared.Item, []shared.Item, error) {
+	first, err := ReadCollection(first)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	second, err = ReadCollection(second)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return first.Games, second.Games, nil
+}
+
+// createDerivedCollection writes games to a new collection, skipping repeated entries and refusing to overwrite an existing one.
+// Nothing is written when no games are left, the returned collection is then empty.
+func createDerivedCollection(collectionMap map[string][]models.Collection, name string, games []shared.Item) (models.Collection, error) {
+	collection := models.Collection{
+		DisplayName:    name,
+		CollectionFile: filepath.Join(GetCollectionDirectory(), name+".txt"),
+	}
+
+	if DoesFileExists(collection.CollectionFile) {
+		return collection, fmt.Errorf("collection %s already exists", name)
+	}
+
+	var unique []shared.Item
+	for _, game := range games {
+		if !containsCollectionEntry(unique, game) {
+			unique = append(unique, game)
+		}
+	}
+
+	if len(unique) == 0 {
+		return collection, nil
+	}
+
+	return AddCollectionGames(collectionMap, collection, unique)
+}
+
+// containsCollectionEntry compares collection lines, unlike containsGame two platforms may share a display name.
+func containsCollectionEntry(games []shared.Item, targetGame shared.Item) bool {
+	return slices.ContainsFunc(games, func(game shared.Item) bool {
+		return game.Path == targetGame.Path
+	})
+}
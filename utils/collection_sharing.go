@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const collectionExportSuffix = ".collection.json"
+
+// ExportCollection describes every game of a collection by platform tag and title, hashing the ROMs that are single files.
+func ExportCollection(collection models.Collection) (models.CollectionExport, error) {
+	export := models.CollectionExport{
+		Name:       collection.DisplayName,
+		ExportedAt: time.Now(),
+	}
+
+	collection, err := ReadCollection(collection)
+	if err != nil {
+		return export, err
+	}
+
+	for _, game := range collection.Games {
+		romPath := collectionEntryToPath(game.Path)
+		name := removeFileExtension(filepath.Base(romPath))
+		if strings.EqualFold(filepath.Ext(romPath), ".m3u") {
+			romPath = filepath.Dir(romPath)
+			name = filepath.Base(romPath)
+		}
+
+		exportGame := models.CollectionExportGame{
+			Title:    strings.TrimSpace(titleTagPattern.ReplaceAllString(name, "")),
+			Platform: strings.Trim(extractTag(platformDirectoryName(romPath)), "()"),
+			Filename: filepath.Base(romPath),
+			Region:   romRegion(name),
+		}
+
+		if info, err := os.Stat(romPath); err == nil && !info.IsDir() {
+			if hashes, err := GetRomHashes(romPath); err == nil {
+				exportGame.SHA1 = hashes.SHA1
+			}
+		}
+
+		export.Games = append(export.Games, exportGame)
+	}
+	SaveHashCache()
+
+	return export, nil
+}
+
+// romRegion is the first tag of a No-Intro style name that isn't a revision, disc or dump flag, e.g. USA for "Game (USA) (Rev 1)".
+func romRegion(name string) string {
+	for _, match := range romTagPattern.FindAllStringSubmatch(name, -1) {
+		tag := strings.TrimSpace(match[1])
+		if revisionPattern.MatchString(tag) || preReleasePattern.MatchString(tag) || unlicensedPattern.MatchString(tag) ||
+			strings.HasPrefix(tag, "Disc") || strings.HasPrefix(tag, "Disk") {
+			continue
+		}
+		return tag
+	}
+	return ""
+}
+
+func SaveCollectionExport(export models.CollectionExport, directory string) (string, error) {
+	if err := EnsureDirectoryExists(directory); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode collection export: %w", err)
+	}
+
+	path := filepath.Join(directory, export.Name+collectionExportSuffix)
+	if err := os.WriteFile(path, data, defaultFilePerm); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+func IsCollectionExportFile(filename string) bool {
+	return strings.HasSuffix(strings.ToLower(filename), collectionExportSuffix)
+}
+
+func ReadCollectionExport(path string) (models.CollectionExport, error) {
+	var export models.CollectionExport
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return export, err
+	}
+
+	if err := json.Unmarshal(data, &export); err != nil {
+		return export, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	if export.Name == "" {
+		export.Name = strings.TrimSuffix(filepath.Base(path), collectionExportSuffix)
+	}
+
+	return export, nil
+}
+
+// platformRoms indexes the library by platform tag and lazily hashes a platform when an entry can only be found by hash.
+type platformRoms struct {
+	roms   map[string][]libraryRom
+	hashes map[string]map[string]libraryRom
+}
+
+func (pr platformRoms) byHash(platform string, sha1 string) (libraryRom, bool) {
+	if _, hashed := pr.hashes[platform]; !hashed {
+		pr.hashes[platform] = make(map[string]libraryRom)
+		for _, rom := range pr.roms[platform] {
+			if rom.IsDirectory {
+				continue
+			}
+			if hashes, err := GetRomHashes(rom.Path); err == nil {
+				pr.hashes[platform][strings.ToLower(hashes.SHA1)] = rom
+			}
+		}
+	}
+
+	rom, found := pr.hashes[platform][strings.ToLower(sha1)]
+	return rom, found
+}
+
+// ImportCollection resolves the exported games against the local library and saves the ones it found as collectionName.
+// Games are matched on the same platform tag by filename, then by title using the hash and region to pick between variants,
+// then by hash alone and finally by fuzzy title.
+func ImportCollection(export models.CollectionExport, collectionName string, threshold float64) (models.CollectionImportResult, error) {
+	result := models.CollectionImportResult{
+		Collection: models.Collection{
+			DisplayName:    collectionName,
+			CollectionFile: filepath.Join(GetCollectionDirectory(), collectionName+".txt"),
+		},
+	}
+
+	roms, err := getLibraryRoms(false)
+	if err != nil {
+		return result, err
+	}
+
+	library := platformRoms{
+		roms:   make(map[string][]libraryRom),
+		hashes: make(map[string]map[string]libraryRom),
+	}
+	for _, rom := range roms {
+		platform := strings.ToUpper(strings.Trim(extractTag(platformDirectoryName(rom.Path)), "()"))
+		library.roms[platform] = append(library.roms[platform], rom)
+	}
+
+	added := make(map[string]bool)
+	for _, game := range export.Games {
+		rom, found := resolveExportGame(game, library, threshold)
+		if !found {
+			result.Unresolved = append(result.Unresolved, game)
+			continue
+		}
+
+		if added[rom.Path] {
+			continue
+		}
+		added[rom.Path] = true
+
+		result.Collection.Games = append(result.Collection.Games, libraryRomItem(rom))
+	}
+	SaveHashCache()
+
+	return result, SaveCollection(result.Collection)
+}
+
+func resolveExportGame(game models.CollectionExportGame, library platformRoms, threshold float64) (libraryRom, bool) {
+	platform := strings.ToUpper(strings.Trim(game.Platform, "()"))
+	candidates := library.roms[platform]
+
+	if game.Filename != "" {
+		for _, rom := range candidates {
+			if strings.EqualFold(filepath.Base(rom.Path), game.Filename) || strings.EqualFold(filepath.Base(rom.Launcher), game.Filename) {
+				return rom, true
+			}
+		}
+	}
+
+	title := strings.ToLower(strings.TrimSpace(titleTagPattern.ReplaceAllString(game.Title, "")))
+
+	var sameTitle []libraryRom
+	for _, rom := range candidates {
+		if libraryRomTitle(rom) == title {
+			sameTitle = append(sameTitle, rom)
+		}
+	}
+
+	if len(sameTitle) > 0 {
+		// a stray track or .bin shares the title of its game, the file NextUI launches goes first
+		slices.SortStableFunc(sameTitle, func(a, b libraryRom) int {
+			return compareLaunchable(cmp.Or(a.Launcher, a.Path), cmp.Or(b.Launcher, b.Path))
+		})
+
+		if game.SHA1 != "" {
+			for _, rom := range sameTitle {
+				if hashes, err := GetRomHashes(rom.Path); err == nil && strings.EqualFold(hashes.SHA1, game.SHA1) {
+					return rom, true
+				}
+			}
+		}
+
+		if game.Region != "" {
+			for _, rom := range sameTitle {
+				if matchesRegion(filepath.Base(rom.Path), strings.Split(game.Region, ",")) {
+					return rom, true
+				}
+			}
+		}
+
+		return sameTitle[0], true
+	}
+
+	if game.SHA1 != "" {
+		if rom, found := library.byHash(platform, game.SHA1); found {
+			return rom, true
+		}
+	}
+
+	var best libraryRom
+	bestScore := 0.0
+	for _, rom := range candidates {
+		if score := titleSimilarity(title, libraryRomTitle(rom)); score > bestScore {
+			best = rom
+			bestScore = score
+		}
+	}
+
+	return best, bestScore >= threshold && bestScore > 0
+}
+
+// libraryRomTitle is the lower case title of a ROM without its tags, multi-disc folders keep dots in their name.
+func libraryRomTitle(rom libraryRom) string {
+	name := filepath.Base(rom.Path)
+	if !rom.IsDirectory {
+		name = removeFileExtension(name)
+	}
+	return strings.ToLower(strings.TrimSpace(titleTagPattern.ReplaceAllString(name, "")))
+}
@@ -0,0 +1,254 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const collectionSortsFilename = "collection_sorts.json"
+
+var releaseYearPattern = regexp.MustCompile(`\(((?:19[7-9]|20[0-9])[0-9])(?:-[0-9]{2}){0,2}\)`)
+
+// loadCollectionSorts returns the remembered sort of every collection keyed by collection name.
+func loadCollectionSorts() map[string]models.CollectionSort {
+	sorts := make(map[string]models.CollectionSort)
+
+	data, err := os.ReadFile(filepath.Join(GetDataDirectory(), collectionSortsFilename))
+	if err != nil {
+		return sorts
+	}
+
+	if err := json.Unmarshal(data, &sorts); err != nil {
+		common.GetLoggerInstance().Error("Failed to parse collection sorts", zap.Error(err))
+	}
+	return sorts
+}
+
+func saveCollectionSorts(sorts map[string]models.CollectionSort) {
+	sortsFile := filepath.Join(GetDataDirectory(), collectionSortsFilename)
+
+	journalFileWrite(sortsFile)
+
+	data, err := json.MarshalIndent(sorts, "", "  ")
+	if err == nil {
+		err = os.WriteFile(sortsFile, data, defaultFilePerm)
+	}
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to save collection sorts", zap.Error(err))
+	}
+}
+
+// GetCollectionSortMode is the sort mode last chosen for a collection, empty when it was never sorted.
+func GetCollectionSortMode(collection models.Collection) string {
+	return loadCollectionSorts()[collection.DisplayName].Mode
+}
+
+// SortCollection orders a collection by mode and remembers the mode so games added later are sorted the same way.
+func SortCollection(collection models.Collection, mode string) (models.Collection, error) {
+	if !slices.Contains(models.CollectionSortModes, mode) {
+		return collection, fmt.Errorf("unknown sort mode %s", mode)
+	}
+
+	collection, err := ReadCollection(collection)
+	if err != nil {
+		return collection, err
+	}
+
+	sorts := loadCollectionSorts()
+	sort := sorts[collection.DisplayName]
+	sort.Mode = mode
+	sorts[collection.DisplayName] = sort
+	saveCollectionSorts(sorts)
+
+	collection.Games = sortCollectionGames(collection.Games, mode, sort.Added)
+	if mode == models.CollectionSortReleaseYear {
+		SaveHashCache()
+	}
+
+	return collection, SaveCollection(collection)
+}
+
+// rememberCollectionAdditions stamps newly added games for the Date Added sort and applies the collection's sort mode.
+func rememberCollectionAdditions(collection models.Collection, added []shared.Item) models.Collection {
+	sorts := loadCollectionSorts()
+	sort := sorts[collection.DisplayName]
+
+	if sort.Added == nil {
+		sort.Added = make(map[string]time.Time)
+	}
+
+	now := time.Now()
+	for _, game := range added {
+		sort.Added[normalizeCollectionGamePath(game)] = now
+	}
+
+	sorts[collection.DisplayName] = sort
+	saveCollectionSorts(sorts)
+
+	if sort.Mode != "" {
+		collection.Games = sortCollectionGames(collection.Games, sort.Mode, sort.Added)
+		if sort.Mode == models.CollectionSortReleaseYear {
+			SaveHashCache()
+		}
+	}
+	return collection
+}
+
+// removeCollectionSort forgets the sort of a deleted collection so a new collection with the same name starts unsorted.
+func removeCollectionSort(name string) {
+	sorts := loadCollectionSorts()
+	if _, found := sorts[name]; !found {
+		return
+	}
+
+	delete(sorts, name)
+	saveCollectionSorts(sorts)
+}
+
+// renameCollectionSort carries the remembered sort over to a renamed collection.
+func renameCollectionSort(oldName string, newName string) {
+	sorts := loadCollectionSorts()
+	sort, found := sorts[oldName]
+	if !found {
+		return
+	}
+
+	delete(sorts, oldName)
+	sorts[newName] = sort
+	saveCollectionSorts(sorts)
+}
+
+// collectionSortKey is what a collection line is compared by, computed once per game rather than per comparison.
+type collectionSortKey struct {
+	title      string
+	platform   string
+	year       int
+	playTime   int
+	lastPlayed int64
+	added      time.Time
+}
+
+// sortCollectionGames orders by mode with the title breaking ties. Games missing a year, play time or added date go last.
+// Titles come from the collection line, which for multi-disc games is the .m3u named after the folder.
+func sortCollectionGames(games []shared.Item, mode string, added map[string]time.Time) []shared.Item {
+	var activity map[string]romActivity
+	if mode == models.CollectionSortPlayTime || mode == models.CollectionSortLastPlayed {
+		activity = activityByTrackerPath()
+	}
+
+	keys := make(map[string]collectionSortKey)
+	for _, game := range games {
+		entry := normalizeCollectionGamePath(game)
+		romPath := collectionEntryToPath(entry)
+		rom := libraryRom{Path: romPath}
+		if strings.EqualFold(filepath.Ext(romPath), ".m3u") {
+			rom = libraryRom{Path: filepath.Dir(romPath), IsDirectory: true}
+		}
+
+		key := collectionSortKey{
+			title:    strings.ToLower(removeFileExtension(filepath.Base(entry))),
+			platform: strings.ToLower(strings.SplitN(strings.TrimPrefix(entry, "/Roms/"), "/", 2)[0]),
+			added:    added[entry],
+		}
+
+		switch mode {
+		case models.CollectionSortReleaseYear:
+			key.year = releaseYear(rom)
+		case models.CollectionSortPlayTime, models.CollectionSortLastPlayed:
+			romActivity := romActivityFor(rom, activity)
+			key.playTime = romActivity.playTime
+			key.lastPlayed = romActivity.lastPlayed
+		}
+
+		keys[entry] = key
+	}
+
+	sorted := slices.Clone(games)
+	slices.SortStableFunc(sorted, func(g1 shared.Item, g2 shared.Item) int {
+		a := keys[normalizeCollectionGamePath(g1)]
+		b := keys[normalizeCollectionGamePath(g2)]
+
+		compared := 0
+		switch mode {
+		case models.CollectionSortPlatform:
+			compared = strings.Compare(a.platform, b.platform)
+		case models.CollectionSortReleaseYear:
+			compared = compareMissingLast(a.year == 0, b.year == 0, a.year-b.year)
+		case models.CollectionSortPlayTime:
+			compared = compareMissingLast(a.playTime == 0, b.playTime == 0, b.playTime-a.playTime)
+		case models.CollectionSortLastPlayed:
+			compared = compareMissingLast(a.lastPlayed == 0, b.lastPlayed == 0, int(b.lastPlayed-a.lastPlayed))
+		case models.CollectionSortDateAdded:
+			compared = compareMissingLast(a.added.IsZero(), b.added.IsZero(), b.added.Compare(a.added))
+		}
+
+		if compared != 0 {
+			return compared
+		}
+		return strings.Compare(a.title, b.title)
+	})
+
+	return sorted
+}
+
+func compareMissingLast(aMissing bool, bMissing bool, compared int) int {
+	switch {
+	case aMissing && bMissing:
+		return 0
+	case aMissing:
+		return 1
+	case bMissing:
+		return -1
+	}
+	return compared
+}
+
+// releaseYear comes from the platform's DAT when one is installed and lists a year, otherwise from a (1998) style filename tag.
+func releaseYear(rom libraryRom) int {
+	if year := datReleaseYear(rom); year > 0 {
+		return year
+	}
+
+	if match := releaseYearPattern.FindStringSubmatch(filepath.Base(rom.Path)); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		return year
+	}
+	return 0
+}
+
+func datReleaseYear(rom libraryRom) int {
+	tag := extractTag(platformDirectoryName(rom.Path))
+	if rom.IsDirectory || !HasDat(tag) {
+		return 0
+	}
+
+	index, _, err := LoadDatIndex(tag)
+	if err != nil {
+		return 0
+	}
+
+	hashes, err := GetRomHashes(rom.Path)
+	if err != nil {
+		return 0
+	}
+
+	entry, found := index.Lookup(hashes)
+	if !found {
+		return 0
+	}
+
+	year, _ := strconv.Atoi(strings.TrimSpace(entry.Year))
+	return year
+}
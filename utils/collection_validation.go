@@ -0,0 +1,301 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"io/fs"
+	"nextui-game-manager/models"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var titleTagPattern = regexp.MustCompile(`\s*[(\[][^)\]]*[)\]]`)
+
+// normalizedTitle strips region, revision and other tags so "Game (USA)" and "Game (Europe) (Rev 1)" compare equal.
+func normalizedTitle(name string) string {
+	return strings.ToLower(strings.TrimSpace(titleTagPattern.ReplaceAllString(removeFileExtension(name), "")))
+}
+
+// collectionEntryToPath maps a collection line such as /Roms/Game Boy (GB)/Tetris.gb onto the ROM directory.
+func collectionEntryToPath(entry string) string {
+	return filepath.Join(GetRomDirectory(), strings.TrimPrefix(entry, "/Roms/"))
+}
+
+func pathToCollectionEntry(path string) string {
+	return strings.Replace(path, GetRomDirectory()+"/", "/Roms/", 1)
+}
+
+type romIndex struct {
+	byName  map[string][]string
+	byTitle map[string][]string
+}
+
+// buildRomIndex walks the ROM directory, archives included, so missing collection entries can be looked up by name.
+func buildRomIndex() (romIndex, error) {
+	index := romIndex{
+		byName:  make(map[string][]string),
+		byTitle: make(map[string][]string),
+	}
+
+	err := filepath.WalkDir(GetRomDirectory(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".media" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		name := strings.ToLower(d.Name())
+		index.byName[name] = append(index.byName[name], path)
+
+		title := normalizedTitle(d.Name())
+		index.byTitle[title] = append(index.byTitle[title], path)
+		return nil
+	})
+
+	if err != nil {
+		return index, fmt.Errorf("failed to index ROM directory: %w", err)
+	}
+
+	return index, nil
+}
+
+// bestCandidate prefers ROMs outside of archives and on the same platform as the missing entry.
+func bestCandidate(candidates []string, platformTag string, extension string) string {
+	best := ""
+	bestScore := -1
+
+	for _, candidate := range candidates {
+		if !strings.EqualFold(filepath.Ext(candidate), extension) {
+			continue
+		}
+
+		score := 0
+		if !isArchivedPath(candidate) {
+			score += 2
+		}
+		if extractTag(platformDirectoryName(filepath.Dir(candidate))) == platformTag {
+			score += 4
+		}
+
+		if score > bestScore {
+			best = candidate
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+func resolveMissingEntry(index romIndex, entry string) (string, string) {
+	missingPath := collectionEntryToPath(entry)
+	platformTag := extractTag(platformDirectoryName(filepath.Dir(missingPath)))
+	extension := filepath.Ext(missingPath)
+
+	resolved := bestCandidate(index.byName[strings.ToLower(filepath.Base(missingPath))], platformTag, extension)
+	resolution := models.CollectionEntryMoved
+
+	if resolved == "" {
+		resolved = bestCandidate(index.byTitle[normalizedTitle(filepath.Base(missingPath))], platformTag, extension)
+		resolution = models.CollectionEntryRenamed
+	}
+
+	if resolved == "" {
+		return "", ""
+	}
+
+	if isArchivedPath(resolved) {
+		resolution = models.CollectionEntryArchived
+	}
+
+	return resolved, resolution
+}
+
+// ValidateCollections scans every collection for lines that no longer resolve to a file and tries to find where they went.
+func ValidateCollections() ([]models.MissingCollectionEntry, error) {
+	collections, code, err := GenerateCollectionList("", false)
+	if err != nil {
+		return nil, err
+	} else if code == 404 {
+		return nil, nil
+	}
+
+	index, err := buildRomIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []models.MissingCollectionEntry
+	for _, collection := range collections {
+		for _, game := range collection.Games {
+			if DoesFileExists(collectionEntryToPath(game.Path)) {
+				continue
+			}
+
+			resolved, resolution := resolveMissingEntry(index, game.Path)
+			missing = append(missing, models.MissingCollectionEntry{
+				Collection:   collection,
+				Entry:        game.Path,
+				ResolvedPath: resolved,
+				Resolution:   resolution,
+			})
+		}
+	}
+
+	return missing, nil
+}
+
+// restoreForCollection brings an archived ROM back and returns the path it was restored to.
+func restoreForCollection(archivedPath string, collectionMap map[string][]models.Collection) (string, error) {
+	romPath := archivedPath
+	multiDisc := strings.EqualFold(filepath.Ext(archivedPath), ".m3u") &&
+		filepath.Base(filepath.Dir(archivedPath)) == removeFileExtension(filepath.Base(archivedPath))
+	if multiDisc {
+		romPath = filepath.Dir(archivedPath)
+	}
+
+	game, romDirectory, err := ResolveRomPath(romPath)
+	if err != nil {
+		return "", err
+	}
+
+	archive, err := ArchiveFromPath(romDirectory.Path)
+	if err != nil {
+		return "", err
+	}
+
+	if err := RestoreRom(game, romDirectory, archive, collectionMap); err != nil {
+		return "", err
+	}
+
+	restoredPath := buildRestorePath(game.Filename, romDirectory, archive)
+	if multiDisc {
+		restoredPath = filepath.Join(restoredPath, filepath.Base(archivedPath))
+	}
+
+	return restoredPath, nil
+}
+
+// rewriteCollection replaces collection lines using replacements; an empty replacement removes the line.
+func rewriteCollection(collection models.Collection, replacements map[string]string) error {
+	loaded, err := ReadCollection(collection)
+	if err != nil {
+		return err
+	}
+
+	var games []string
+	for _, game := range loaded.Games {
+		path := game.Path
+		if replacement, found := replacements[path]; found {
+			path = replacement
+		}
+
+		if path == "" || slices.Contains(games, path) {
+			continue
+		}
+		games = append(games, path)
+	}
+
+	loaded.Games = nil
+	for _, path := range games {
+		loaded.Games = append(loaded.Games, shared.Item{DisplayName: collectionDisplayName(path), Path: path})
+	}
+
+	return SaveCollection(loaded)
+}
+
+func groupByCollection(entries []models.MissingCollectionEntry) map[string][]models.MissingCollectionEntry {
+	grouped := make(map[string][]models.MissingCollectionEntry)
+	for _, entry := range entries {
+		grouped[entry.Collection.CollectionFile] = append(grouped[entry.Collection.CollectionFile], entry)
+	}
+	return grouped
+}
+
+// FixCollectionEntries points resolvable entries at their new location, restoring archived ROMs first.
+// It returns the number of entries that were fixed.
+func FixCollectionEntries(entries []models.MissingCollectionEntry, collectionMap map[string][]models.Collection) (int, error) {
+	logger := common.GetLoggerInstance()
+
+	BeginOperation(fmt.Sprintf("Fix %d Collection Entries", len(entries)))
+	defer CommitOperation()
+
+	restored := make(map[string]string)
+	fixed := 0
+
+	var lastErr error
+	for _, group := range groupByCollection(entries) {
+		replacements := make(map[string]string)
+
+		for _, entry := range group {
+			if !entry.Resolvable() {
+				continue
+			}
+
+			newPath := entry.ResolvedPath
+			if entry.Resolution == models.CollectionEntryArchived {
+				if previous, found := restored[entry.ResolvedPath]; found {
+					newPath = previous
+				} else {
+					restoredPath, err := restoreForCollection(entry.ResolvedPath, collectionMap)
+					if err != nil {
+						logger.Error("Failed to restore archived ROM", zap.String("path", entry.ResolvedPath), zap.Error(err))
+						lastErr = err
+						continue
+					}
+					restored[entry.ResolvedPath] = restoredPath
+					newPath = restoredPath
+				}
+			}
+
+			replacements[entry.Entry] = pathToCollectionEntry(newPath)
+		}
+
+		if len(replacements) == 0 {
+			continue
+		}
+
+		if err := rewriteCollection(group[0].Collection, replacements); err != nil {
+			logger.Error("Failed to fix collection", zap.String("collection", group[0].Collection.DisplayName), zap.Error(err))
+			lastErr = err
+			continue
+		}
+
+		fixed += len(replacements)
+	}
+
+	return fixed, lastErr
+}
+
+// PruneCollectionEntries removes the given entries from their collections.
+func PruneCollectionEntries(entries []models.MissingCollectionEntry) error {
+	BeginOperation(fmt.Sprintf("Prune %d Collection Entries", len(entries)))
+	defer CommitOperation()
+
+	var lastErr error
+	for _, group := range groupByCollection(entries) {
+		replacements := make(map[string]string)
+		for _, entry := range group {
+			replacements[entry.Entry] = ""
+		}
+
+		if err := rewriteCollection(group[0].Collection, replacements); err != nil {
+			common.GetLoggerInstance().Error("Failed to prune collection", zap.String("collection", group[0].Collection.DisplayName), zap.Error(err))
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
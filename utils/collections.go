@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/filebrowser"
@@ -15,18 +16,16 @@ import (
 	"time"
 )
 
-func updateCollectionGamePath(collection models.Collection, oldDisplayName string, game shared.Item, romDirectory shared.RomDirectory) {
-	romDirectoryStub := strings.ReplaceAll(romDirectory.Path, GetRomDirectory(), "")
+func DeleteCollection(collection models.Collection) {
+	BeginOperation(fmt.Sprintf("Delete collection %s", collection.DisplayName))
+	defer CommitOperation()
 
-	for i, item := range collection.Games {
-		if item.DisplayName == oldDisplayName {
-			collection.Games[i].Path = filepath.Join(romDirectoryStub, game.Filename)
-		}
+	if err := TrashFile(collection.CollectionFile); err != nil {
+		common.GetLoggerInstance().Error("Failed to delete collection", zap.Error(err))
+		return
 	}
-}
 
-func DeleteCollection(collection models.Collection) {
-	common.DeleteFile(collection.CollectionFile)
+	removeCollectionSort(collection.DisplayName)
 }
 
 func AddCollectionGames(collectionMap map[string][]models.Collection, collection models.Collection, games []shared.Item) (models.Collection, error) {
@@ -42,14 +41,18 @@ func AddCollectionGames(collectionMap map[string][]models.Collection, collection
 		}
 	}
 
+	var added []shared.Item
 	for _, game := range games {
 		if GameExistsInCollection(collectionMap, collection, game) {
 			logger.Debug("Game already exists in collection", zap.String("path", game.Path))
 			continue
 		}
 		collection.Games = append(collection.Games, game)
+		added = append(added, game)
 	}
 
+	collection = rememberCollectionAdditions(collection, added)
+
 	return collection, SaveCollection(collection)
 }
 
@@ -99,20 +102,13 @@ func ReadCollection(collection models.Collection) (models.Collection, error) {
 	return collection, nil
 }
 
-func AlphabetizeCollection(collection models.Collection) models.Collection {
-	slices.SortFunc(collection.Games, func(g1 shared.Item, g2 shared.Item) int {
-		return strings.Compare(g1.DisplayName, g2.DisplayName)
-	})
-
-	SaveCollection(collection)
-	return collection
-}
-
 func SaveCollection(collection models.Collection) error {
 	if err := EnsureDirectoryExists(filepath.Dir(collection.CollectionFile)); err != nil {
 		return fmt.Errorf("failed to create collection directory: %w", err)
 	}
 
+	journalFileWrite(collection.CollectionFile)
+
 	file, err := os.OpenFile(collection.CollectionFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, defaultFilePerm)
 	if err != nil {
 		return fmt.Errorf("failed to open collection file: %w", err)
@@ -142,8 +138,204 @@ func normalizeCollectionGamePath(game shared.Item) string {
 	return path
 }
 
-func findCollectionsContainingGame(collectionMap map[string][]models.Collection, game shared.Item) []models.Collection {
-	return collectionMap[game.DisplayName]
+func collectionDisplayName(entry string) string {
+	return strings.ReplaceAll(filepath.Base(entry), filepath.Ext(entry), "")
+}
+
+// collectionEntryForRom returns the line NextUI expects in a collection file for a ROM at romPath.
+func collectionEntryForRom(game shared.Item, romPath string) string {
+	game.Path = romPath
+	if game.IsMultiDiscDirectory {
+		game.DisplayName = filepath.Base(romPath)
+	}
+	return normalizeCollectionGamePath(game)
+}
+
+func collectionsReferencing(collectionMap map[string][]models.Collection, entry string) []models.Collection {
+	var collections []models.Collection
+	for _, collection := range collectionMap[collectionDisplayName(entry)] {
+		if !slices.ContainsFunc(collections, func(c models.Collection) bool {
+			return c.CollectionFile == collection.CollectionFile
+		}) {
+			collections = append(collections, collection)
+		}
+	}
+	return collections
+}
+
+// syncCollectionEntries points every collection line matching oldEntry at newEntry, or removes it when newEntry is empty.
+// It returns the collection files that were rewritten.
+func syncCollectionEntries(collectionMap map[string][]models.Collection, oldEntry string, newEntry string) []string {
+	logger := common.GetLoggerInstance()
+
+	var updated []string
+	for _, collection := range collectionsReferencing(collectionMap, oldEntry) {
+		loaded, err := ReadCollection(collection)
+		if err != nil {
+			continue
+		}
+
+		var games shared.Items
+		changed := false
+		for _, game := range loaded.Games {
+			if game.Path != oldEntry {
+				games = append(games, game)
+				continue
+			}
+
+			changed = true
+			if newEntry != "" {
+				games = append(games, shared.Item{
+					DisplayName: collectionDisplayName(newEntry),
+					Path:        newEntry,
+				})
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		loaded.Games = games
+		if err := SaveCollection(loaded); err != nil {
+			logger.Error("Failed to update collection", zap.String("file", loaded.CollectionFile), zap.Error(err))
+			continue
+		}
+
+		updated = append(updated, loaded.CollectionFile)
+	}
+
+	return updated
+}
+
+type archivedCollectionMembership struct {
+	CollectionFile string `json:"collection_file"`
+	Entry          string `json:"entry"`
+}
+
+const archivedCollectionsFilename = "archived_collections.json"
+
+func loadArchivedCollections() map[string][]archivedCollectionMembership {
+	memberships := make(map[string][]archivedCollectionMembership)
+
+	data, err := os.ReadFile(filepath.Join(GetDataDirectory(), archivedCollectionsFilename))
+	if err != nil {
+		return memberships
+	}
+
+	if err := json.Unmarshal(data, &memberships); err != nil {
+		common.GetLoggerInstance().Error("Failed to parse archived collections", zap.Error(err))
+	}
+	return memberships
+}
+
+func saveArchivedCollections(memberships map[string][]archivedCollectionMembership) {
+	data, err := json.MarshalIndent(memberships, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(GetDataDirectory(), archivedCollectionsFilename), data, defaultFilePerm)
+	}
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to save archived collections", zap.Error(err))
+	}
+}
+
+// loadGeneratedCollections returns the names of the collections Game Manager wrote itself, as listed in manifestFilename.
+// Any other collection file belongs to the user and is never overwritten or removed by generated collections.
+func loadGeneratedCollections(manifestFilename string) []string {
+	var names []string
+
+	data, err := os.ReadFile(filepath.Join(GetDataDirectory(), manifestFilename))
+	if err != nil {
+		return names
+	}
+
+	if err := json.Unmarshal(data, &names); err != nil {
+		common.GetLoggerInstance().Error("Failed to parse generated collections", zap.String("file", manifestFilename), zap.Error(err))
+	}
+	return names
+}
+
+func saveGeneratedCollections(manifestFilename string, names []string) {
+	manifestPath := filepath.Join(GetDataDirectory(), manifestFilename)
+
+	var err error
+	if len(names) == 0 {
+		if err = os.Remove(manifestPath); os.IsNotExist(err) {
+			err = nil
+		}
+	} else {
+		var data []byte
+		if data, err = json.MarshalIndent(names, "", "  "); err == nil {
+			err = os.WriteFile(manifestPath, data, defaultFilePerm)
+		}
+	}
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to save generated collections", zap.String("file", manifestFilename), zap.Error(err))
+	}
+}
+
+// isUserCollection reports whether a collection with this name exists that Game Manager did not generate.
+func isUserCollection(name string, generated []string) bool {
+	return !slices.Contains(generated, name) && DoesFileExists(filepath.Join(GetCollectionDirectory(), name+".txt"))
+}
+
+// removeArchivedRomFromCollections drops the ROM from every collection and remembers where it was so RestoreRom can re-add it.
+func removeArchivedRomFromCollections(collectionMap map[string][]models.Collection, entry string, archivedPath string) {
+	collectionFiles := syncCollectionEntries(collectionMap, entry, "")
+	if len(collectionFiles) == 0 {
+		return
+	}
+
+	memberships := loadArchivedCollections()
+	for _, collectionFile := range collectionFiles {
+		memberships[archivedPath] = append(memberships[archivedPath], archivedCollectionMembership{
+			CollectionFile: collectionFile,
+			Entry:          entry,
+		})
+	}
+	saveArchivedCollections(memberships)
+}
+
+func restoreArchivedRomToCollections(archivedPath string, entry string) {
+	logger := common.GetLoggerInstance()
+
+	memberships := loadArchivedCollections()
+	restored, found := memberships[archivedPath]
+	if !found {
+		return
+	}
+
+	for _, membership := range restored {
+		if !DoesFileExists(membership.CollectionFile) {
+			continue
+		}
+
+		collection, err := ReadCollection(models.Collection{
+			DisplayName:    collectionDisplayName(membership.CollectionFile),
+			CollectionFile: membership.CollectionFile,
+		})
+		if err != nil {
+			continue
+		}
+
+		if slices.ContainsFunc(collection.Games, func(game shared.Item) bool { return game.Path == entry }) {
+			continue
+		}
+
+		collection.Games = append(collection.Games, shared.Item{
+			DisplayName: collectionDisplayName(entry),
+			Path:        entry,
+		})
+
+		if err := SaveCollection(collection); err != nil {
+			logger.Error("Failed to restore collection entry", zap.String("file", collection.CollectionFile), zap.Error(err))
+		}
+	}
+
+	delete(memberships, archivedPath)
+	saveArchivedCollections(memberships)
 }
 
 func containsGame(games []shared.Item, targetGame shared.Item) bool {
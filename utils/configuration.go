@@ -33,6 +33,13 @@ func SaveConfig(config *models.Config) error {
 	viper.Set("log_level", config.LogLevel)
 	viper.Set("play_history_show_collections", config.PlayHistoryShowCollections)
 	viper.Set("play_history_show_archives", config.PlayHistoryShowArchives)
+	viper.Set("region_priority", config.RegionPriority)
+	viper.Set("backlog_completed_collection", config.BacklogCompletedCollection)
+	viper.Set("auto_collections", config.AutoCollections)
+	viper.Set("save_backup_directory", config.SaveBackupDirectory)
+	viper.Set("save_backup_keep", config.SaveBackupKeep)
+	viper.Set("save_backup_interval_days", config.SaveBackupIntervalDays)
+	viper.Set("trash_retention_days", config.TrashRetentionDays)
 
 
 	return viper.WriteConfigAs(configFile)
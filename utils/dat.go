@@ -0,0 +1,406 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const datDirectoryName = "dats"
+
+type logiqxDatafile struct {
+	Games    []logiqxGame `xml:"game"`
+	Machines []logiqxGame `xml:"machine"`
+}
+
+type logiqxGame struct {
+	Name string      `xml:"name,attr"`
+	Year string      `xml:"year"`
+	Roms []logiqxRom `xml:"rom"`
+}
+
+type logiqxRom struct {
+	Name   string `xml:"name,attr"`
+	Size   string `xml:"size,attr"`
+	CRC    string `xml:"crc,attr"`
+	MD5    string `xml:"md5,attr"`
+	SHA1   string `xml:"sha1,attr"`
+	Status string `xml:"status,attr"`
+}
+
+// DatIndex holds every ROM from a platform's DAT files keyed by hash.
+type DatIndex struct {
+	bySHA1 map[string]models.DatEntry
+	byMD5  map[string]models.DatEntry
+	byCRC  map[string][]models.DatEntry
+}
+
+func (d DatIndex) Lookup(hashes models.RomHashes) (models.DatEntry, bool) {
+	if entry, found := d.bySHA1[hashes.SHA1]; found && hashes.SHA1 != "" {
+		return entry, true
+	}
+
+	if entry, found := d.byMD5[hashes.MD5]; found && hashes.MD5 != "" {
+		return entry, true
+	}
+
+	for _, entry := range d.byCRC[hashes.CRC32] {
+		if entry.Size == 0 || entry.Size == hashes.Size {
+			return entry, true
+		}
+	}
+
+	return models.DatEntry{}, false
+}
+
+var (
+	datIndexMutex sync.Mutex
+	datIndexes    = make(map[string]DatIndex)
+)
+
+// GetDatDirectory is where No-Intro / Redump DATs live, either as <TAG>.dat or inside a <TAG> folder.
+func GetDatDirectory() string {
+	dir := filepath.Join(GetDataDirectory(), datDirectoryName)
+	_ = EnsureDirectoryExists(dir)
+	return dir
+}
+
+func datFilesForPlatform(tag string) []string {
+	platform := cleanTag(tag)
+	if platform == "" {
+		return nil
+	}
+
+	var datFiles []string
+
+	single := filepath.Join(GetDatDirectory(), platform+".dat")
+	if DoesFileExists(single) {
+		datFiles = append(datFiles, single)
+	}
+
+	nested, _ := filepath.Glob(filepath.Join(GetDatDirectory(), platform, "*.dat"))
+	datFiles = append(datFiles, nested...)
+
+	return datFiles
+}
+
+func HasDat(tag string) bool {
+	return len(datFilesForPlatform(tag)) > 0
+}
+
+// LoadDatIndex parses and caches the DAT files for a platform tag. The boolean is false when no DAT is installed.
+func LoadDatIndex(tag string) (DatIndex, bool, error) {
+	datIndexMutex.Lock()
+	defer datIndexMutex.Unlock()
+
+	if index, found := datIndexes[tag]; found {
+		return index, true, nil
+	}
+
+	datFiles := datFilesForPlatform(tag)
+	if len(datFiles) == 0 {
+		return DatIndex{}, false, nil
+	}
+
+	index := DatIndex{
+		bySHA1: make(map[string]models.DatEntry),
+		byMD5:  make(map[string]models.DatEntry),
+		byCRC:  make(map[string][]models.DatEntry),
+	}
+
+	for _, datFile := range datFiles {
+		if err := parseDatFile(datFile, index); err != nil {
+			return DatIndex{}, true, err
+		}
+	}
+
+	datIndexes[tag] = index
+	return index, true, nil
+}
+
+func parseDatFile(datFile string, index DatIndex) error {
+	data, err := os.ReadFile(datFile)
+	if err != nil {
+		return fmt.Errorf("failed to read DAT %s: %w", datFile, err)
+	}
+
+	var datafile logiqxDatafile
+	if err := xml.Unmarshal(data, &datafile); err != nil {
+		return fmt.Errorf("failed to parse DAT %s: %w", datFile, err)
+	}
+
+	for _, game := range append(datafile.Games, datafile.Machines...) {
+		for _, rom := range game.Roms {
+			size, _ := strconv.ParseInt(rom.Size, 10, 64)
+			entry := models.DatEntry{
+				GameName: game.Name,
+				RomName:  rom.Name,
+				Size:     size,
+				CRC32:    strings.ToLower(rom.CRC),
+				MD5:      strings.ToLower(rom.MD5),
+				SHA1:     strings.ToLower(rom.SHA1),
+				Status:   rom.Status,
+				Year:     game.Year,
+			}
+
+			if entry.SHA1 != "" {
+				index.bySHA1[entry.SHA1] = entry
+			}
+			if entry.MD5 != "" {
+				index.byMD5[entry.MD5] = entry
+			}
+			if entry.CRC32 != "" {
+				index.byCRC[entry.CRC32] = append(index.byCRC[entry.CRC32], entry)
+			}
+		}
+	}
+
+	return nil
+}
+
+// canonicalFilename is the name the DAT expects, keeping .zip for zipped ROMs.
+func canonicalFilename(entry models.DatEntry, romFilename string) string {
+	if strings.EqualFold(filepath.Ext(romFilename), ".zip") {
+		return entry.GameName + filepath.Ext(romFilename)
+	}
+	return entry.RomName
+}
+
+// FindCanonicalName looks a ROM up in its platform's DAT and returns the canonical name without extension.
+// Only a hash already cached by verification, duplicates or sorting is used, the ROM is never hashed here.
+func FindCanonicalName(romPath string, tag string) (string, bool) {
+	hashes, found := cachedRomHashes(romPath)
+	if !found {
+		return "", false
+	}
+
+	index, found, err := LoadDatIndex(tag)
+	if err != nil || !found {
+		return "", false
+	}
+
+	entry, found := index.Lookup(hashes)
+	if !found {
+		return "", false
+	}
+
+	return entry.GameName, true
+}
+
+// VerifyRomDirectory hashes every ROM on a platform and checks it against the platform's DATs.
+func VerifyRomDirectory(romDirectory shared.RomDirectory) ([]models.VerificationResult, error) {
+	logger := common.GetLoggerInstance()
+
+	index, found, err := LoadDatIndex(romDirectory.Tag)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("no DAT installed for %s", romDirectory.DisplayName)
+	}
+
+	roms, err := walkRoms(romDirectory.Path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer SaveHashCache()
+
+	var results []models.VerificationResult
+	for _, libraryRom := range roms {
+		rom, _, err := ResolveRomPath(libraryRom.Path)
+		if err != nil {
+			continue
+		}
+
+		result := models.VerificationResult{Game: rom, Status: models.VerificationUnknown}
+
+		if rom.IsDirectory {
+			results = append(results, result)
+			continue
+		}
+
+		if isSheet(rom.Path) {
+			results = append(results, verifyTrackSheet(index, result))
+			continue
+		}
+
+		hashes, err := GetRomHashes(rom.Path)
+		if err != nil {
+			logger.Error("Failed to hash ROM", zap.String("path", rom.Path), zap.Error(err))
+			results = append(results, result)
+			continue
+		}
+
+		entry, found := index.Lookup(hashes)
+		if !found {
+			results = append(results, result)
+			continue
+		}
+
+		result.CanonicalName = canonicalFilename(entry, rom.Filename)
+
+		switch {
+		case entry.Status == "baddump":
+			result.Status = models.VerificationBadDump
+		case removeFileExtension(rom.Filename) != removeFileExtension(result.CanonicalName):
+			result.Status = models.VerificationMisnamed
+		default:
+			result.Status = models.VerificationVerified
+		}
+
+		results = append(results, result)
+	}
+
+	statusOrder := []string{models.VerificationMisnamed, models.VerificationBadDump, models.VerificationUnknown, models.VerificationVerified}
+	slices.SortStableFunc(results, func(a, b models.VerificationResult) int {
+		if a.Status != b.Status {
+			return slices.Index(statusOrder, a.Status) - slices.Index(statusOrder, b.Status)
+		}
+		return strings.Compare(a.Game.Filename, b.Game.Filename)
+	})
+
+	return results, nil
+}
+
+// verifyTrackSheet checks a .cue or .gdi game by its track files, the sheet itself is often regenerated and is
+// verified by name only. Every track has to belong to the same DAT game.
+func verifyTrackSheet(index DatIndex, result models.VerificationResult) models.VerificationResult {
+	tracks, err := sheetTrackNames(index, result.Game.Path)
+	if err != nil || len(tracks) == 0 {
+		return result
+	}
+
+	var gameName string
+	for _, entry := range tracks {
+		gameName = entry.GameName
+		break
+	}
+
+	misnamed := removeFileExtension(result.Game.Filename) != gameName
+	badDump := false
+	for track, entry := range tracks {
+		if entry.GameName != gameName {
+			return result
+		}
+		misnamed = misnamed || track != entry.RomName
+		badDump = badDump || entry.Status == "baddump"
+	}
+
+	result.CanonicalName = gameName + filepath.Ext(result.Game.Filename)
+	switch {
+	case badDump:
+		result.Status = models.VerificationBadDump
+	case misnamed:
+		result.Status = models.VerificationMisnamed
+	default:
+		result.Status = models.VerificationVerified
+	}
+
+	return result
+}
+
+// sheetTrackNames looks up every track a .cue or .gdi points at, keyed by the track's file name. It fails when a track
+// is not in the DAT, an .m3u has no tracks of its own and returns nothing.
+func sheetTrackNames(index DatIndex, sheetPath string) (map[string]models.DatEntry, error) {
+	if trackFilePattern(sheetPath) == nil {
+		return nil, nil
+	}
+
+	tracks := make(map[string]models.DatEntry)
+	for _, track := range romCompanions(sheetPath) {
+		hashes, err := GetRomHashes(track)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, found := index.Lookup(hashes)
+		if !found {
+			return nil, fmt.Errorf("%s is not in the DAT", filepath.Base(track))
+		}
+		tracks[filepath.Base(track)] = entry
+	}
+
+	return tracks, nil
+}
+
+// RenameToCanonical renames a misnamed ROM to its DAT name so art, saves, collections and play history follow.
+// The tracks of a .cue or .gdi are renamed along with it and the sheet is pointed at their new names.
+func RenameToCanonical(result models.VerificationResult, collectionMap map[string][]models.Collection) (string, error) {
+	game, romDirectory, err := ResolveRomPath(result.Game.Path)
+	if err != nil {
+		return "", err
+	}
+
+	if directoryCompanions(romDirectory.Path)[game.Filename] {
+		return "", fmt.Errorf("%s is a track of another game", game.Filename)
+	}
+
+	newName := removeFileExtension(result.CanonicalName)
+	newPath := buildNewRomPath(romDirectory.Path, newName, game.Filename)
+	if newPath != game.Path && DoesFileExists(newPath) {
+		return "", fmt.Errorf("%s already exists", newName)
+	}
+
+	BeginOperation(fmt.Sprintf("Rename %s to %s", game.DisplayName, newName))
+	defer CommitOperation()
+
+	if err := renameSheetTracksToCanonical(game.Path, romDirectory.Tag); err != nil {
+		return "", err
+	}
+
+	if newPath == game.Path {
+		return game.Filename, nil
+	}
+
+	return RenameRom(game, newName, romDirectory, collectionMap)
+}
+
+// renameSheetTracksToCanonical renames the tracks of a .cue or .gdi to their DAT names and rewrites the sheet to match.
+func renameSheetTracksToCanonical(sheetPath string, tag string) error {
+	if trackFilePattern(sheetPath) == nil {
+		return nil
+	}
+
+	index, found, err := LoadDatIndex(tag)
+	if err != nil || !found {
+		return err
+	}
+
+	tracks, err := sheetTrackNames(index, sheetPath)
+	if err != nil {
+		return err
+	}
+
+	directory := filepath.Dir(sheetPath)
+	renames := make(map[string]string)
+	for track, entry := range tracks {
+		if track == entry.RomName {
+			continue
+		}
+		if DoesFileExists(filepath.Join(directory, entry.RomName)) {
+			return fmt.Errorf("%s already exists", entry.RomName)
+		}
+		renames[track] = entry.RomName
+	}
+
+	// the sheet follows whatever tracks were renamed, so a failed rename never leaves it pointing at missing files
+	renamed := make(map[string]string)
+	for track, canonical := range renames {
+		if err := MoveFile(filepath.Join(directory, track), filepath.Join(directory, canonical)); err != nil {
+			_ = renameSheetTracks(sheetPath, renamed)
+			return err
+		}
+		renamed[track] = canonical
+	}
+
+	return renameSheetTracks(sheetPath, renamed)
+}
@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// discImageLaunchers are the files NextUI launches a disc based game from, best first.
+var discImageLaunchers = []string{".m3u", ".cue", ".gdi", ".chd", ".pbp"}
+
+var (
+	cueFilePattern = regexp.MustCompile(`(?i)^\s*FILE\s+(?:"([^"]+)"|(\S+))`)
+	gdiFilePattern = regexp.MustCompile(`^\s*\d+\s+\d+\s+\d+\s+\d+\s+(?:"([^"]+)"|(\S+))`)
+	discTagPattern = regexp.MustCompile(`(?i)\s*[(\[]\s*(?:disc|disk|cd|track|side)\s*[^)\]]*[)\]]`)
+)
+
+func isSheet(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".cue" || ext == ".gdi" || ext == ".m3u"
+}
+
+// trackFilePattern matches the track lines of a .cue or .gdi, an .m3u lists its discs one per line instead.
+func trackFilePattern(sheetPath string) *regexp.Regexp {
+	switch strings.ToLower(filepath.Ext(sheetPath)) {
+	case ".cue":
+		return cueFilePattern
+	case ".gdi":
+		return gdiFilePattern
+	default:
+		return nil
+	}
+}
+
+// sheetReferences lists the files a .cue, .gdi or .m3u points at, relative to the sheet.
+func sheetReferences(sheetPath string) []string {
+	file, err := os.Open(sheetPath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	pattern := trackFilePattern(sheetPath)
+
+	var references []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if pattern != nil {
+			if match := pattern.FindStringSubmatch(line); match != nil {
+				references = append(references, match[1]+match[2])
+			}
+			continue
+		}
+
+		if line != "" && !strings.HasPrefix(line, "#") {
+			references = append(references, line)
+		}
+	}
+
+	return references
+}
+
+// romCompanions lists the files next to a .cue, .gdi or .m3u that belong to it, following an .m3u into the cue sheets of its
+// discs. Companions are part of the game and move, trash and count along with the sheet.
+func romCompanions(romPath string) []string {
+	if !isSheet(romPath) {
+		return nil
+	}
+
+	directory := filepath.Dir(romPath)
+	var companions []string
+	pending := []string{romPath}
+	for len(pending) > 0 {
+		sheet := pending[0]
+		pending = pending[1:]
+
+		for _, reference := range sheetReferences(sheet) {
+			path := filepath.Join(filepath.Dir(sheet), reference)
+			if filepath.Dir(path) != directory || path == romPath || slices.Contains(companions, path) || !DoesFileExists(path) {
+				continue
+			}
+
+			companions = append(companions, path)
+			if isSheet(path) {
+				pending = append(pending, path)
+			}
+		}
+	}
+
+	return companions
+}
+
+// renameSheetTracks points the track lines of a .cue or .gdi at the renamed files, renames maps old to new file names.
+func renameSheetTracks(sheetPath string, renames map[string]string) error {
+	pattern := trackFilePattern(sheetPath)
+	if pattern == nil || len(renames) == 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(sheetPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", sheetPath, err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		match := pattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		start, end, quoted := match[4], match[5], false
+		if match[2] != -1 {
+			start, end, quoted = match[2], match[3], true
+		}
+
+		renamed, found := renames[line[start:end]]
+		if !found {
+			continue
+		}
+		if !quoted && strings.Contains(renamed, " ") {
+			renamed = `"` + renamed + `"`
+		}
+		lines[i] = line[:start] + renamed + line[end:]
+	}
+
+	journalFileWrite(sheetPath)
+	if err := os.WriteFile(sheetPath, []byte(strings.Join(lines, "\n")), defaultFilePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", sheetPath, err)
+	}
+
+	return nil
+}
+
+// directoryCompanions is the set of file names in a folder that some sheet in it points at.
+func directoryCompanions(directory string) map[string]bool {
+	companions := make(map[string]bool)
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return companions
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isSheet(entry.Name()) {
+			continue
+		}
+		for _, companion := range romCompanions(filepath.Join(directory, entry.Name())) {
+			companions[filepath.Base(companion)] = true
+		}
+	}
+
+	return companions
+}
+
+// folderLauncher returns the file NextUI launches for a game folder, which makes the folder a single ROM: the .m3u of a
+// multi-disc folder, the file named after a self-contained folder, or the only sheet of a folder holding nothing but
+// that sheet and its files. Any other folder returns "".
+func folderLauncher(directory string) string {
+	name := filepath.Base(directory)
+	if m3u := filepath.Join(directory, name+".m3u"); DoesFileExists(m3u) {
+		return m3u
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return ""
+	}
+
+	var named []string
+	var sheets []string
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(directory, entry.Name())
+		files = append(files, path)
+		if removeFileExtension(entry.Name()) == name {
+			named = append(named, path)
+		}
+		if isSheet(path) {
+			sheets = append(sheets, path)
+		}
+	}
+
+	if len(named) > 0 {
+		slices.SortStableFunc(named, compareLaunchable)
+		return named[0]
+	}
+
+	if len(sheets) == 1 {
+		companions := romCompanions(sheets[0])
+		if len(companions)+1 == len(files) {
+			return sheets[0]
+		}
+	}
+
+	return ""
+}
+
+// compareLaunchable orders paths so the file NextUI would launch a disc based game from comes first.
+func compareLaunchable(a, b string) int {
+	return launchableRank(a) - launchableRank(b)
+}
+
+func launchableRank(path string) int {
+	if index := slices.Index(discImageLaunchers, strings.ToLower(filepath.Ext(path))); index != -1 {
+		return index
+	}
+	if strings.EqualFold(filepath.Ext(path), ".bin") || discTagPattern.MatchString(removeFileExtension(filepath.Base(path))) {
+		return len(discImageLaunchers) + 1
+	}
+	return len(discImageLaunchers)
+}
+
+// discTags returns the disc, track and side tags of a ROM name, e.g. " (Disc 2)".
+func discTags(name string) string {
+	return strings.Join(discTagPattern.FindAllString(removeFileExtension(name), -1), "")
+}
+
+// variantTitle is the normalized title with the disc and track tags kept, so regional variants of a game share a title
+// while the discs and tracks of one game never do.
+func variantTitle(name string) string {
+	return normalizedTitle(name) + strings.ToLower(discTags(name))
+}
@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"archive/zip"
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// FindDuplicates groups every ROM, archives included, by content hash and by normalized title per platform. Titles keep
+// their disc and track tags, so the discs of one game never end up in the same group.
+// Only ROMs whose data size collides with another ROM are hashed, which keeps the scan reasonable on device.
+func FindDuplicates(collectionMap map[string][]models.Collection) ([]models.DuplicateGroup, error) {
+	logger := common.GetLoggerInstance()
+
+	roms, err := getLibraryRoms(true)
+	if err != nil {
+		return nil, err
+	}
+
+	playTimes := playTimeByTrackerPath()
+
+	duplicateRoms := make(map[string]models.DuplicateRom)
+	bySize := make(map[int64][]string)
+	byTitle := make(map[string][]string)
+
+	for _, rom := range roms {
+		duplicateRoms[rom.Path] = models.DuplicateRom{
+			Path:        rom.Path,
+			Size:        rom.Size,
+			PlayTime:    romPlayTime(rom, playTimes),
+			Collections: romCollections(rom, collectionMap),
+			Archived:    isArchivedPath(rom.Path),
+		}
+
+		platform := extractTag(platformDirectoryName(filepath.Dir(rom.Path)))
+		titleKey := platform + "|" + variantTitle(filepath.Base(rom.Path))
+		byTitle[titleKey] = append(byTitle[titleKey], rom.Path)
+
+		if !rom.IsDirectory {
+			dataSize := romDataSize(rom.Path, rom.Size)
+			bySize[dataSize] = append(bySize[dataSize], rom.Path)
+		}
+	}
+
+	var groups []models.DuplicateGroup
+	var hashGroups [][]string
+
+	bySHA1 := make(map[string][]string)
+	for _, paths := range bySize {
+		if len(paths) < 2 {
+			continue
+		}
+
+		for _, path := range paths {
+			hashes, err := GetRomHashes(path)
+			if err != nil {
+				logger.Error("Failed to hash ROM", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			bySHA1[hashes.SHA1] = append(bySHA1[hashes.SHA1], path)
+		}
+	}
+	SaveHashCache()
+
+	for _, paths := range bySHA1 {
+		if len(paths) < 2 {
+			continue
+		}
+
+		slices.Sort(paths)
+		hashGroups = append(hashGroups, paths)
+		groups = append(groups, buildDuplicateGroup(paths, models.DuplicateIdentical, duplicateRoms))
+	}
+
+	for _, paths := range byTitle {
+		if len(paths) < 2 {
+			continue
+		}
+
+		slices.Sort(paths)
+		if slices.ContainsFunc(hashGroups, func(hashGroup []string) bool { return slices.Equal(hashGroup, paths) }) {
+			continue
+		}
+
+		groups = append(groups, buildDuplicateGroup(paths, models.DuplicateSameTitle, duplicateRoms))
+	}
+
+	slices.SortFunc(groups, func(a, b models.DuplicateGroup) int {
+		if c := strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Reason, b.Reason)
+	})
+
+	return groups, nil
+}
+
+func buildDuplicateGroup(paths []string, reason string, duplicateRoms map[string]models.DuplicateRom) models.DuplicateGroup {
+	group := models.DuplicateGroup{
+		Title:  strings.TrimSpace(titleTagPattern.ReplaceAllString(removeFileExtension(filepath.Base(paths[0])), "")),
+		Reason: reason,
+	}
+
+	for _, path := range paths {
+		group.Roms = append(group.Roms, duplicateRoms[path])
+	}
+
+	return group
+}
+
+// romDataSize is the size the hash will be computed over, which for zips is the largest file inside.
+func romDataSize(path string, size int64) int64 {
+	if !strings.EqualFold(filepath.Ext(path), ".zip") {
+		return size
+	}
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return size
+	}
+	defer reader.Close()
+
+	var largest uint64
+	for _, file := range reader.File {
+		if file.UncompressedSize64 > largest {
+			largest = file.UncompressedSize64
+		}
+	}
+
+	return int64(largest)
+}
+
+// trackerPathForRom maps a ROM, archived or not, onto the path the game tracker recorded when it was played.
+func trackerPathForRom(path string) string {
+	relativePath, err := filepath.Rel(GetRomDirectory(), path)
+	if err != nil {
+		return path
+	}
+
+	if isArchivedPath(path) {
+		parts := strings.SplitN(relativePath, string(filepath.Separator), 2)
+		if len(parts) == 2 {
+			return parts[1]
+		}
+	}
+
+	return relativePath
+}
+
+func romPlayTime(rom libraryRom, playTimes map[string]int) int {
+	trackerPath := trackerPathForRom(rom.Path)
+	if !rom.IsDirectory {
+		return playTimes[trackerPath]
+	}
+
+	total := 0
+	for path, playTime := range playTimes {
+		if strings.HasPrefix(path, trackerPath+"/") {
+			total += playTime
+		}
+	}
+	return total
+}
+
+func playTimeByTrackerPath() map[string]int {
+	logger := common.GetLoggerInstance()
+	playTimes := make(map[string]int)
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return playTimes
+	}
+
+	rows, err := db.Query("SELECT rom.file_path, SUM(play_activity.play_time) " +
+		"FROM rom JOIN play_activity ON rom.id = play_activity.rom_id " +
+		"GROUP BY rom.id")
+	if err != nil {
+		logger.Error("Failed to load play times", zap.Error(err))
+		return playTimes
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var filePath string
+		var playTime int
+		if err := rows.Scan(&filePath, &playTime); err != nil {
+			logger.Error("Failed to read play time", zap.Error(err))
+			continue
+		}
+		playTimes[unarchivedTrackerPath(filePath)] += playTime
+	}
+
+	return playTimes
+}
+
+func romCollections(rom libraryRom, collectionMap map[string][]models.Collection) []string {
+	entry := normalizeCollectionGamePath(libraryRomItem(rom))
+
+	var names []string
+	for _, collection := range collectionsReferencing(collectionMap, entry) {
+		if slices.ContainsFunc(collection.Games, func(game shared.Item) bool { return game.Path == entry }) {
+			names = append(names, collection.DisplayName)
+		}
+	}
+	return names
+}
+
+// PreferredDuplicate picks the copy to keep by default: the most played, then one outside of an archive.
+func PreferredDuplicate(group models.DuplicateGroup) int {
+	preferred := 0
+	for i, rom := range group.Roms {
+		current := group.Roms[preferred]
+		if rom.PlayTime > current.PlayTime || (rom.PlayTime == current.PlayTime && current.Archived && !rom.Archived) {
+			preferred = i
+		}
+	}
+	return preferred
+}
+
+// ResolveDuplicateGroup keeps one ROM of the group and archives or deletes the rest.
+func ResolveDuplicateGroup(group models.DuplicateGroup, keep int, deleteOthers bool, collectionMap map[string][]models.Collection) error {
+	logger := common.GetLoggerInstance()
+
+	archives, err := GetArchiveFileList()
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for i, rom := range group.Roms {
+		if i == keep || (rom.Archived && !deleteOthers) {
+			continue
+		}
+
+		game, romDirectory, err := ResolveRomPath(rom.Path)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if deleteOthers {
+			DeleteRom(game, romDirectory, collectionMap)
+			continue
+		}
+
+		if err := ArchiveRom(game, romDirectory, archives[0], collectionMap); err != nil {
+			logger.Error("Failed to archive duplicate", zap.String("path", rom.Path), zap.Error(err))
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("failed to resolve duplicates of %s: %w", group.Title, lastErr)
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
 	"go.uber.org/zap"
+	"io"
+	"nextui-game-manager/models"
 	"os"
 	"path/filepath"
 )
@@ -42,17 +44,87 @@ func MoveFile(sourcePath, destinationPath string) error {
 		return fmt.Errorf("failed to move file from %s to %s: %w", sourcePath, destinationPath, err)
 	}
 
+	recordJournalEntry(models.JournalEntry{
+		Type:        models.JournalEntryMove,
+		Source:      sourcePath,
+		Destination: destinationPath,
+	})
+
+	return nil
+}
+
+// CopyFile copies a file keeping its modification time. Undo removes the copy unless it replaced an existing file.
+func CopyFile(sourcePath, destinationPath string) error {
+	if err := EnsureDirectoryExists(filepath.Dir(destinationPath)); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", sourcePath, err)
+	}
+
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", sourcePath, err)
+	}
+	defer source.Close()
+
+	replacing := DoesFileExists(destinationPath)
+
+	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, defaultFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", destinationPath, err)
+	}
+
+	if _, err := io.Copy(destination, source); err != nil {
+		destination.Close()
+		return fmt.Errorf("failed to copy %s to %s: %w", sourcePath, destinationPath, err)
+	}
+
+	if err := destination.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", destinationPath, err)
+	}
+
+	_ = os.Chtimes(destinationPath, info.ModTime(), info.ModTime())
+
+	if !replacing {
+		recordJournalEntry(models.JournalEntry{
+			Type:        models.JournalEntryCopy,
+			Source:      sourcePath,
+			Destination: destinationPath,
+		})
+	}
+
 	return nil
 }
 
-func DeleteRom(game shared.Item, romDirectory shared.RomDirectory) {
+func DeleteRom(game shared.Item, romDirectory shared.RomDirectory, collectionMap map[string][]models.Collection) {
+	BeginOperation(fmt.Sprintf("Delete %s", game.DisplayName))
+	defer CommitOperation()
+
 	romPath := filepath.Join(romDirectory.Path, game.Filename)
-	if common.DeleteFile(romPath) {
-		DeleteArt(game.Filename, romDirectory)
+	companions := romCompanions(romPath)
+	if err := TrashFile(romPath); err != nil {
+		common.GetLoggerInstance().Error("Failed to delete ROM", zap.String("path", romPath), zap.Error(err))
+		return
 	}
+
+	for _, companion := range companions {
+		if err := TrashFile(companion); err != nil {
+			common.GetLoggerInstance().Error("Failed to delete ROM companion", zap.String("path", companion), zap.Error(err))
+		}
+	}
+
+	syncCollectionEntries(collectionMap, collectionEntryForRom(game, romPath), "")
+	removeBacklogEntry(romPath)
+	DeleteArt(game.Filename, romDirectory)
 }
 
-func Nuke(game shared.Item, romDirectory shared.RomDirectory) {
+func Nuke(game shared.Item, romDirectory shared.RomDirectory, collectionMap map[string][]models.Collection) {
+	BeginOperation(fmt.Sprintf("Nuke %s", game.DisplayName))
+	defer CommitOperation()
+
 	ClearGameTracker(game.Filename, romDirectory)
-	DeleteRom(game, romDirectory)
+	DeleteRom(game, romDirectory, collectionMap)
 }
@@ -6,8 +6,10 @@ import (
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/filebrowser"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
 	_ "github.com/mattn/go-sqlite3"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 )
@@ -15,7 +17,10 @@ import (
 const (
 	gameTrackerDBPath  = "/mnt/SDCARD/.userdata/shared/game_logs.sqlite"
 	saveFileDirectory  = "/mnt/SDCARD/Saves/"
+	saveStateDirectory = "/mnt/SDCARD/.userdata/shared/"
 	RecentlyPlayedFile = "/mnt/SDCARD/.userdata/shared/.minui/recent.txt"
+	dataDirectory      = "/mnt/SDCARD/.userdata/shared/game-manager/"
+	exportDirectory    = "/mnt/SDCARD/Exports/"
 	defaultDirPerm     = 0755
 	defaultFilePerm    = 0644
 )
@@ -56,6 +61,14 @@ func GetSaveFileDirectory() string {
 	return saveFileDirectory
 }
 
+// GetSaveStateDirectory is the shared userdata folder holding the <TAG>-<core> state folders and the .minui slot previews.
+func GetSaveStateDirectory() string {
+	if IsDev() {
+		return os.Getenv("SAVE_STATE_DIRECTORY")
+	}
+	return saveStateDirectory
+}
+
 func GetGameTrackerDBPath() string {
 	if IsDev() {
 		return os.Getenv("GAME_TRACKER_DB_PATH")
@@ -63,6 +76,27 @@ func GetGameTrackerDBPath() string {
 	return gameTrackerDBPath
 }
 
+// GetExportDirectory is a visible folder on the SD card for files meant to be copied off the device.
+func GetExportDirectory() string {
+	dir := exportDirectory
+	if IsDev() {
+		dir = os.Getenv("EXPORT_DIRECTORY")
+	}
+
+	_ = EnsureDirectoryExists(dir)
+	return dir
+}
+
+func GetDataDirectory() string {
+	dir := dataDirectory
+	if IsDev() {
+		dir = os.Getenv("DATA_DIRECTORY")
+	}
+
+	_ = EnsureDirectoryExists(dir)
+	return dir
+}
+
 func CreateRomDirectoryFromItem(item shared.Item) shared.RomDirectory {
 	return shared.RomDirectory{
 		DisplayName: item.DisplayName,
@@ -71,6 +105,69 @@ func CreateRomDirectoryFromItem(item shared.Item) shared.RomDirectory {
 	}
 }
 
+// ResolveRomPath builds the Item and RomDirectory pair the rest of utils expects from a plain ROM path.
+func ResolveRomPath(romPath string) (shared.Item, shared.RomDirectory, error) {
+	absPath, err := filepath.Abs(romPath)
+	if err != nil {
+		return shared.Item{}, shared.RomDirectory{}, fmt.Errorf("failed to resolve path %s: %w", romPath, err)
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return shared.Item{}, shared.RomDirectory{}, fmt.Errorf("failed to find ROM %s: %w", romPath, err)
+	}
+
+	filename := filepath.Base(absPath)
+	item := shared.Item{
+		DisplayName: removeFileExtension(filename),
+		Filename:    filename,
+		Path:        absPath,
+		IsDirectory: info.IsDir(),
+	}
+
+	if info.IsDir() {
+		item.DisplayName = filename
+		item.IsMultiDiscDirectory = DoesFileExists(filepath.Join(absPath, filename+".m3u"))
+	}
+
+	parent := filepath.Dir(absPath)
+	romDirectory := shared.RomDirectory{
+		DisplayName: filepath.Base(parent),
+		Tag:         extractTag(platformDirectoryName(parent)),
+		Path:        parent,
+	}
+
+	return item, romDirectory, nil
+}
+
+func platformDirectoryName(romDirectoryPath string) string {
+	relativePath, err := filepath.Rel(GetRomDirectory(), romDirectoryPath)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return filepath.Base(romDirectoryPath)
+	}
+
+	parts := strings.Split(relativePath, string(filepath.Separator))
+	if strings.HasPrefix(parts[0], ".") && len(parts) > 1 {
+		return parts[1]
+	}
+	return parts[0]
+}
+
+var tagPattern = regexp.MustCompile(`\([^()]+\)$`)
+
+func extractTag(directoryName string) string {
+	return tagPattern.FindString(strings.TrimSpace(directoryName))
+}
+
+// platformDisplayName drops the tag from a platform folder, "Game Boy Advance (GBA)" becomes "Game Boy Advance".
+func platformDisplayName(directoryName string) string {
+	name := strings.TrimSpace(strings.Replace(directoryName, extractTag(directoryName), "", 1))
+	if name == "" {
+		return directoryName
+	}
+	return name
+}
+
 func FilterList(itemList []shared.Item, keywords ...string) []shared.Item {
 	if len(keywords) == 0 {
 		return itemList
@@ -141,16 +238,6 @@ func getRomFilesRecursive(dirPath string) ([]shared.Item, error) {
 	return romFiles, err
 }
 
-func findSaveFile(items []shared.Item, targetFilename string) shared.Item {
-	lowerTarget := strings.ToLower(targetFilename)
-	for _, item := range items {
-		if strings.Contains(strings.ToLower(item.Filename), lowerTarget) {
-			return item
-		}
-	}
-	return shared.Item{}
-}
-
 func cleanTag(tag string) string {
 	cleaned := strings.ReplaceAll(tag, "(", "")
 	return strings.ReplaceAll(cleaned, ")", "")
@@ -159,3 +246,160 @@ func cleanTag(tag string) string {
 func removeFileExtension(filename string) string {
 	return strings.TrimSuffix(filename, filepath.Ext(filename))
 }
+
+// GetRomDirectories lists the top level platform folders, skipping ports and archives.
+func GetRomDirectories() ([]shared.RomDirectory, error) {
+	fb := filebrowser.NewFileBrowser(common.GetLoggerInstance())
+	if err := fb.CWD(GetRomDirectory(), false); err != nil {
+		return nil, fmt.Errorf("failed to get rom directories: %w", err)
+	}
+
+	var romDirectories []shared.RomDirectory
+	for _, item := range fb.Items {
+		if !item.IsDirectory || item.Tag == "(PORTS)" || strings.HasPrefix(item.Filename, ".") {
+			continue
+		}
+		romDirectories = append(romDirectories, CreateRomDirectoryFromItem(item))
+	}
+
+	return romDirectories, nil
+}
+
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGT"[exp])
+}
+
+type libraryRom struct {
+	Path        string
+	Size        int64
+	IsDirectory bool
+	// Launcher is the file collections point at: the ROM itself, or the .m3u or launchable file of a game folder
+	Launcher string
+}
+
+// libraryRomItem builds the collection item of a ROM, multi-disc folders are written as their .m3u and other game
+// folders as the file NextUI launches.
+func libraryRomItem(rom libraryRom) shared.Item {
+	name := filepath.Base(rom.Path)
+	if rom.IsDirectory && rom.Launcher == filepath.Join(rom.Path, name+".m3u") {
+		return shared.Item{
+			DisplayName:          name,
+			Filename:             name,
+			Path:                 rom.Path,
+			IsDirectory:          true,
+			IsMultiDiscDirectory: true,
+		}
+	}
+
+	launcher := rom.Path
+	if rom.Launcher != "" {
+		launcher = rom.Launcher
+	}
+
+	return shared.Item{
+		DisplayName: removeFileExtension(filepath.Base(launcher)),
+		Filename:    filepath.Base(launcher),
+		Path:        launcher,
+	}
+}
+
+// getLibraryRoms walks every platform folder, and optionally the archives, treating game folders as a single ROM.
+// Files a cue sheet or .m3u points at are part of that ROM rather than ROMs of their own.
+func getLibraryRoms(includeArchives bool) ([]libraryRom, error) {
+	return walkRoms(GetRomDirectory(), includeArchives)
+}
+
+func walkRoms(root string, includeArchives bool) ([]libraryRom, error) {
+	var roms []libraryRom
+	companions := make(map[string]map[string]bool)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if path == root {
+			return nil
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".media" || extractTag(d.Name()) == "(PORTS)" {
+				return filepath.SkipDir
+			}
+
+			// archives are the hidden folders at the top, hidden folders inside them hold archived states and orphans
+			if strings.HasPrefix(d.Name(), ".") && (!includeArchives || filepath.Dir(path) != root) {
+				return filepath.SkipDir
+			}
+
+			// platform folders are never a game, the folders inside them can be
+			if !strings.Contains(mirroredRomDirectory(path), string(filepath.Separator)) {
+				return nil
+			}
+
+			if launcher := folderLauncher(path); launcher != "" {
+				roms = append(roms, libraryRom{Path: path, Size: directorySize(path), IsDirectory: true, Launcher: launcher})
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || filepath.Dir(path) == GetRomDirectory() {
+			return nil
+		}
+
+		directory := filepath.Dir(path)
+		if companions[directory] == nil {
+			companions[directory] = directoryCompanions(directory)
+		}
+		if companions[directory][d.Name()] {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		size := info.Size()
+		for _, companion := range romCompanions(path) {
+			if companionInfo, err := os.Stat(companion); err == nil {
+				size += companionInfo.Size()
+			}
+		}
+
+		roms = append(roms, libraryRom{Path: path, Size: size, Launcher: path})
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan ROM directory: %w", err)
+	}
+
+	return roms, nil
+}
+
+func directorySize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
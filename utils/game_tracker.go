@@ -2,6 +2,7 @@ package utils
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
@@ -11,16 +12,15 @@ import (
 	"maps"
 	"slices"
 	"strings"
-	"strconv"
+	"sync"
 	"time"
 )
 
 func HasGameTrackerData(romFilename string, romDirectory shared.RomDirectory) bool {
-	db, err := openGameTrackerDB()
+	db, err := getGameTrackerDB()
 	if err != nil {
 		return false
 	}
-	defer closeDB(db)
 
 	gameTrackerRomPath := buildGameTrackerPath(romDirectory.Path, romFilename)
 
@@ -37,11 +37,10 @@ func buildGameTrackerPath(romDirectoryPath, filename string) string {
 func MigrateGameTrackerData(filename, oldPath, newPath string) bool {
 	logger := common.GetLoggerInstance()
 
-	db, err := openGameTrackerDB()
+	db, err := getGameTrackerDB()
 	if err != nil {
 		return false
 	}
-	defer closeDB(db)
 
 	logger.Debug("Migrating game tracker data",
 		zap.String("filename", filename),
@@ -59,6 +58,50 @@ func updateGameTrackerForRename(oldFilename, newFilename string, romDirectory sh
 	MigrateGameTrackerData(newFilename, oldPath, newPath)
 }
 
+// moveGameTrackerPaths points the tracker rows of a ROM that moved at its new path, keeping their names.
+// A multi-disc folder moves the rows of every disc inside it.
+func moveGameTrackerPaths(oldPath, newPath string) {
+	logger := common.GetLoggerInstance()
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return
+	}
+
+	rows, err := db.Query("SELECT name, file_path FROM rom WHERE file_path = ? OR substr(file_path, 1, ?) = ?",
+		oldPath, len(oldPath)+1, oldPath+"/")
+	if err != nil {
+		logger.Error("Failed to load game tracker data", zap.Error(err))
+		return
+	}
+
+	renames := make(map[string]string)
+	for rows.Next() {
+		var name string
+		var filePath string
+		if err := rows.Scan(&name, &filePath); err != nil {
+			logger.Error("Failed to load game tracker data", zap.Error(err))
+			continue
+		}
+		renames[filePath] = name
+	}
+	rows.Close()
+
+	for filePath, name := range renames {
+		MigrateGameTrackerData(name, filePath, newPath+strings.TrimPrefix(filePath, oldPath))
+	}
+}
+
+// unarchivedTrackerPath drops the archive folder from a tracker path, so a ROM keeps its console and play time
+// whether it was recorded before or after being archived.
+func unarchivedTrackerPath(trackerPath string) string {
+	parts := strings.SplitN(trackerPath, "/", 2)
+	if len(parts) == 2 && strings.HasPrefix(parts[0], ".") && parts[0] != ".media" {
+		return parts[1]
+	}
+	return trackerPath
+}
+
 func findRomID(tx *sql.Tx, romPath string) (string, error) {
 	var romID string
 	err := tx.QueryRow("SELECT id FROM rom WHERE file_path = ?", romPath).Scan(&romID)
@@ -84,6 +127,12 @@ func executeGameTrackerMigration(db *sql.DB, filename, oldPath, newPath string,
 		return false
 	}
 
+	var oldName string
+	if err := tx.QueryRow("SELECT name FROM rom WHERE id = ?", romID).Scan(&oldName); err != nil {
+		logger.Error("Failed to load ROM name", zap.Error(err))
+		return false
+	}
+
 	if err := updateRomData(tx, filename, newPath, romID); err != nil {
 		logger.Error("Failed to update ROM data", zap.Error(err))
 		return false
@@ -94,20 +143,29 @@ func executeGameTrackerMigration(db *sql.DB, filename, oldPath, newPath string,
 		return false
 	}
 
+	recordJournalEntry(models.JournalEntry{
+		Type:        models.JournalEntryTrackerPath,
+		Source:      oldPath,
+		Destination: newPath,
+		OldName:     oldName,
+		NewName:     filename,
+	})
+
 	logger.Info("Game tracker ROM data updated successfully")
 	return true
 }
 
 func ClearGameTracker(romName string, romDirectory shared.RomDirectory) bool {
+	return clearGameTrackerPath(buildGameTrackerPath(romDirectory.Path, romName))
+}
+
+func clearGameTrackerPath(romPath string) bool {
 	logger := common.GetLoggerInstance()
 
-	db, err := openGameTrackerDB()
+	db, err := getGameTrackerDB()
 	if err != nil {
 		return false
 	}
-	defer closeDB(db)
-
-	romPath := buildGameTrackerPath(romDirectory.Path, romName)
 
 	tx, err := db.Begin()
 	if err != nil {
@@ -122,6 +180,12 @@ func ClearGameTracker(romName string, romDirectory shared.RomDirectory) bool {
 		return false
 	}
 
+	snapshot, err := snapshotGameTrackerData(tx, romID)
+	if err != nil {
+		logger.Error("Failed to snapshot game tracker data", zap.Error(err))
+		return false
+	}
+
 	if err := deleteGameTrackerData(tx, romID); err != nil {
 		logger.Error("Failed to delete game tracker data", zap.Error(err))
 		return false
@@ -132,6 +196,12 @@ func ClearGameTracker(romName string, romDirectory shared.RomDirectory) bool {
 		return false
 	}
 
+	recordJournalEntry(models.JournalEntry{
+		Type:        models.JournalEntryTrackerDelete,
+		Source:      romPath,
+		TrackerRows: snapshot,
+	})
+
 	logger.Info("Game tracker data cleared successfully")
 	return true
 }
@@ -144,17 +214,263 @@ func deleteGameTrackerData(tx *sql.Tx, romID string) error {
 	return err
 }
 
-func openGameTrackerDB() (*sql.DB, error) {
-	logger := common.GetLoggerInstance()
+func snapshotGameTrackerData(tx *sql.Tx, romID string) (*models.TrackerSnapshot, error) {
+	romRows, err := queryRowMaps(tx, "SELECT * FROM rom WHERE id = ?", romID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(romRows) == 0 {
+		return nil, fmt.Errorf("no rom row found for id %s", romID)
+	}
+
+	activityRows, err := queryRowMaps(tx, "SELECT * FROM play_activity WHERE rom_id = ?", romID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TrackerSnapshot{
+		Rom:          romRows[0],
+		PlayActivity: activityRows,
+	}, nil
+}
+
+func queryRowMaps(tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	db, err := sql.Open("sqlite3", GetGameTrackerDBPath())
+	columns, err := rows.Columns()
 	if err != nil {
-		logger.Error("Failed to open game tracker database", zap.Error(err))
 		return nil, err
 	}
+
+	var result []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if raw, ok := values[i].([]byte); ok {
+				row[column] = string(raw)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+func restoreGameTrackerSnapshot(snapshot *models.TrackerSnapshot) error {
+	if snapshot == nil || len(snapshot.Rom) == 0 {
+		return fmt.Errorf("journal entry has no game tracker snapshot")
+	}
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertGameTrackerSnapshot(tx, snapshot); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertGameTrackerSnapshot(tx *sql.Tx, snapshot *models.TrackerSnapshot) error {
+	if err := insertRowMap(tx, "rom", snapshot.Rom); err != nil {
+		return fmt.Errorf("failed to restore rom row: %w", err)
+	}
+
+	for _, activity := range snapshot.PlayActivity {
+		if err := insertRowMap(tx, "play_activity", activity); err != nil {
+			return fmt.Errorf("failed to restore play activity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeGameTrackerHistory moves every play session of one rom row onto another and removes the emptied row.
+func mergeGameTrackerHistory(sourcePath, targetPath string) error {
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sourceID, err := findRomID(tx, sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %w", sourcePath, err)
+	}
+
+	targetID, err := findRomID(tx, targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %w", targetPath, err)
+	}
+
+	snapshot, err := snapshotGameTrackerData(tx, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot game tracker data: %w", err)
+	}
+
+	if _, err := tx.Exec("UPDATE play_activity SET rom_id = ? WHERE rom_id = ?", targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to move play activity: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM rom WHERE id = ?", sourceID); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", sourcePath, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	recordJournalEntry(models.JournalEntry{
+		Type:        models.JournalEntryTrackerMerge,
+		Source:      sourcePath,
+		Destination: targetPath,
+		TrackerRows: snapshot,
+	})
+
+	return nil
+}
+
+// undoGameTrackerMerge takes the merged sessions back off the target row and restores the original row.
+func undoGameTrackerMerge(entry models.JournalEntry) error {
+	if entry.TrackerRows == nil || len(entry.TrackerRows.Rom) == 0 {
+		return fmt.Errorf("journal entry has no game tracker snapshot")
+	}
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	targetID, err := findRomID(tx, entry.Destination)
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %w", entry.Destination, err)
+	}
+
+	for _, activity := range entry.TrackerRows.PlayActivity {
+		_, err := tx.Exec("DELETE FROM play_activity WHERE ROWID = "+
+			"(SELECT ROWID FROM play_activity WHERE rom_id = ? AND created_at = ? AND play_time = ? LIMIT 1)",
+			targetID, normalizeJSONValue(activity["created_at"]), normalizeJSONValue(activity["play_time"]))
+		if err != nil {
+			return fmt.Errorf("failed to separate play activity: %w", err)
+		}
+	}
+
+	if err := insertGameTrackerSnapshot(tx, entry.TrackerRows); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertRowMap(tx *sql.Tx, table string, row map[string]interface{}) error {
+	columns := slices.Sorted(maps.Keys(row))
+
+	quoted := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		quoted[i] = `"` + strings.ReplaceAll(column, `"`, `""`) + `"`
+		placeholders[i] = "?"
+		values[i] = normalizeJSONValue(row[column])
+	}
+
+	_, err := tx.Exec("INSERT INTO "+table+" ("+strings.Join(quoted, ", ")+") VALUES ("+strings.Join(placeholders, ", ")+")", values...)
+	return err
+}
+
+func normalizeJSONValue(value interface{}) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+
+	if i, err := number.Int64(); err == nil {
+		return i
+	}
+	if f, err := number.Float64(); err == nil {
+		return f
+	}
+	return number.String()
+}
+
+var (
+	gameTrackerMutex sync.Mutex
+	gameTrackerDB    *sql.DB
+	gameTrackerDBFor string
+)
+
+// getGameTrackerDB returns the shared game tracker handle, opening it on first use. Callers must not close it.
+func getGameTrackerDB() (*sql.DB, error) {
+	gameTrackerMutex.Lock()
+	defer gameTrackerMutex.Unlock()
+
+	path := GetGameTrackerDBPath()
+	if gameTrackerDB != nil && gameTrackerDBFor == path {
+		return gameTrackerDB, nil
+	}
+
+	if gameTrackerDB != nil {
+		closeDB(gameTrackerDB)
+		gameTrackerDB = nil
+	}
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to open game tracker database", zap.Error(err))
+		return nil, fmt.Errorf("failed to open game tracker database: %w", err)
+	}
+
+	gameTrackerDB = db
+	gameTrackerDBFor = path
 	return db, nil
 }
 
+func CloseGameTrackerDB() {
+	gameTrackerMutex.Lock()
+	defer gameTrackerMutex.Unlock()
+
+	if gameTrackerDB != nil {
+		closeDB(gameTrackerDB)
+		gameTrackerDB = nil
+	}
+}
+
 func closeDB(db *sql.DB) {
 	if err := db.Close(); err != nil {
 		logger := common.GetLoggerInstance()
@@ -165,12 +481,16 @@ func closeDB(db *sql.DB) {
 func FindRomHomeFromAggregate(gameAggregate models.PlayHistoryAggregate, showArchives bool) string {
 	gamePath := gameAggregate.Path
 	if DoesFileExists(gamePath) {
-		if showArchives {
-			return "(+) "
+		if !showArchives {
+			return ""
 		}
-		return ""
+		if archive, err := ArchiveFromPath(gamePath); err == nil {
+			return "(" + string(CleanArchiveName(archive.DisplayName)[0]) + ") "
+		}
+		return "(+) "
 	}
 
+	// history recorded before archiving carried the tracker along still points at the platform folder
 	archiveList, err := GetArchiveFileListBasic()
 	if err == nil {
 		for _, archiveName := range archiveList {
@@ -197,7 +517,7 @@ func CollectGameAggregateFromGamePath(gamePath string, console string, gamePlayM
 	PlayHistoryList := gamePlayMap[console]
 
 	for _, gameAggregate := range PlayHistoryList {
-		if gameAggregate.Path == gamePath {
+		if trackerPathForRom(gameAggregate.Path) == trackerPathForRom(gamePath) {
 			return gameAggregate
 		}
 	}
@@ -209,153 +529,131 @@ func CollectGameAggregateFromGamePath(gamePath string, console string, gamePlayM
 	}
 }
 
-func convertIntListToStringList(intList []int) []string {
-	var stringList []string
-	for _, i := range intList {
-		stringList = append(stringList, strconv.Itoa(i))
-	}
-	return stringList
-}
-
 const (
 	NoFilter		= 0   // No filter
 	YearMonth       = 1   // YearMonth Filter
 )
 
-func GenFiltersList(romIds []int, searchFilterString string, existingFilterType int) []models.PlayHistorySearchFilter {
-	logger := common.GetLoggerInstance()
-	db, err := openGameTrackerDB()
+// GenFiltersList groups the play time inside scope by month, or by day once a month has been picked.
+// Each returned filter carries the date range of its group.
+func GenFiltersList(scope models.PlayHistoryQuery, existingFilterType int) ([]models.PlayHistorySearchFilter, error) {
+	db, err := getGameTrackerDB()
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	defer closeDB(db)
 
-	// If any roms are selected by parents, filter for those roms
-	romWhereStr := ""
-	if len(romIds) > 0 {
-		romWhereStr = " rom_id in ('" + strings.Join(convertIntListToStringList(romIds), "','") + "') "
+	bucket, found := playHistoryBuckets[existingFilterType]
+	if !found {
+		bucket = playHistoryBuckets[NoFilter]
 	}
 
-	// rom filters or search filters are present, include WHERE clause
-	whereStr := ""
-	if romWhereStr != "" || searchFilterString != "" {
-		whereStr = " WHERE "
-	}
+	groupBy := "STRFTIME(?, DATETIME(play_activity.created_at, 'unixepoch', 'localtime'))"
+	whereClause, whereArgs := compilePlayHistoryQuery(scope)
 
-	// rom filters and search filters are present, include AND clause
-	andStr := ""
-	if romWhereStr != "" && searchFilterString != "" {
-		andStr = " AND "
-	}
+	args := append([]interface{}{bucket.sqlFormat}, whereArgs...)
+	args = append(args, bucket.sqlFormat)
 
-	// Generate date string modifier constructs
-	newFilterFormat := ""
-	switch existingFilterType {
-	case YearMonth:
-		newFilterFormat = "STRFTIME('%Y.%m.%d', DATETIME(play_activity.created_at, 'unixepoch', 'localtime'))"
-	default:
-		newFilterFormat = "STRFTIME('%Y.%m', DATETIME(play_activity.created_at, 'unixepoch', 'localtime'))"
+	rows, err := db.Query("SELECT "+groupBy+" AS new_filter, "+
+		"SUM(play_activity.play_time) AS play_time "+
+		"FROM play_activity "+
+		"JOIN rom ON rom.id = play_activity.rom_id "+
+		whereClause+
+		"GROUP BY "+groupBy, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load play history filters: %w", err)
 	}
-
-	rows, err := db.Query("SELECT " + newFilterFormat + " as new_filter, " + 
-						  "sum(play_activity.play_time) as play_time " +
-        				  "FROM play_activity " +
-						  whereStr + romWhereStr + andStr + searchFilterString +
-        				  "GROUP BY " + newFilterFormat)
 	defer rows.Close()
 
 	var filterList []models.PlayHistorySearchFilter
 	for rows.Next() {
-		var newFilter 	string
-		var playTime 	int
+		var newFilter string
+		var playTime int
 		if err := rows.Scan(&newFilter, &playTime); err != nil {
-			logger.Error("Failed to load game tracker data", zap.Error(err))
+			return nil, fmt.Errorf("failed to load play history filters: %w", err)
 		}
 
-		singleFilter := models.PlayHistorySearchFilter{
-			DisplayName:	newFilter,
-			SqlFilter: 		newFilterFormat + " = '" + newFilter + "'",
-			FilterType:		existingFilterType + 1,
-			PlayTime:		playTime,
+		start, err := time.ParseInLocation(bucket.layout, newFilter, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected play history date %s: %w", newFilter, err)
 		}
 
-		filterList = append(filterList, singleFilter)
+		filterList = append(filterList, models.PlayHistorySearchFilter{
+			DisplayName: newFilter,
+			Query:       models.PlayHistoryQuery{Start: start, End: bucket.next(start)},
+			FilterType:  existingFilterType + 1,
+			PlayTime:    playTime,
+		})
 	}
 
-	return filterList
+	return filterList, rows.Err()
 }
 
-func GenerateSingleGameGranularRecords(romIds []int, searchFilter string) []models.PlayHistoryGranular {
-	if len(romIds) == 0 {
-		return nil
+// GenerateSingleGameGranularRecords lists the individual play sessions matching query, which must name the ROM ids.
+func GenerateSingleGameGranularRecords(query models.PlayHistoryQuery) ([]models.PlayHistoryGranular, error) {
+	if len(query.RomIds) == 0 {
+		return nil, nil
 	}
-	
-	logger := common.GetLoggerInstance()
-	db, err := openGameTrackerDB()
+
+	db, err := getGameTrackerDB()
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	defer closeDB(db)
 
-	romIdString := strings.Join(convertIntListToStringList(romIds), "','")
+	whereClause, args := compilePlayHistoryQuery(query)
 
-	whereMod := ""
-	if searchFilter != "" {
-		whereMod = " and " + searchFilter + " "
+	rows, err := db.Query("SELECT play_activity.play_time, play_activity.created_at, play_activity.updated_at "+
+		"FROM play_activity "+
+		"JOIN rom ON rom.id = play_activity.rom_id "+
+		whereClause+
+		"ORDER BY play_activity.created_at", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load play sessions: %w", err)
 	}
-
-	rows, err := db.Query("SELECT play_time, created_at, updated_at " +
-        				  "FROM play_activity " +
-						  "WHERE rom_id in ('"+romIdString+"') " +
-						  whereMod +
-        				  "ORDER BY created_at")
 	defer rows.Close()
 
 	var granularList []models.PlayHistoryGranular
 	for rows.Next() {
-		var playTime 	int
-		var createTime 	int
-		var updateTime 	int
+		var playTime int
+		var createTime int
+		var updateTime sql.NullInt64
 		if err := rows.Scan(&playTime, &createTime, &updateTime); err != nil {
-			logger.Error("Failed to load game tracker data", zap.Error(err))
+			return nil, fmt.Errorf("failed to load play sessions: %w", err)
 		}
 
-		playTrack := models.PlayHistoryGranular{
-			PlayTime:	playTime,
-			StartTime: 	createTime,
-			UpdateTime:	updateTime,
-		}
-		granularList = append(granularList, playTrack)
+		granularList = append(granularList, models.PlayHistoryGranular{
+			PlayTime:   playTime,
+			StartTime:  createTime,
+			UpdateTime: int(updateTime.Int64),
+		})
 	}
 
-	return granularList
+	return granularList, rows.Err()
 }
 
-func GenerateCurrentGameStats(searchFilter string) (map[string][]models.PlayHistoryAggregate, map[string]int, int) {
-	logger := common.GetLoggerInstance()
-	db, err := openGameTrackerDB()
+// GenerateCurrentGameStats aggregates play time per game and per console for the play activity matching query.
+func GenerateCurrentGameStats(query models.PlayHistoryQuery) (map[string][]models.PlayHistoryAggregate, map[string]int, int, error) {
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	whereClause, args := compilePlayHistoryQuery(query)
+
+	rows, err := db.Query("SELECT rom.id, rom.name, rom.file_path, "+
+		"SUM(play_activity.play_time) AS play_time_total, "+
+		"COUNT(play_activity.ROWID) AS play_count_total, "+
+		"MIN(play_activity.created_at) AS first_played_at, "+
+		"MAX(play_activity.created_at) AS last_played_at "+
+		"FROM rom "+
+		"LEFT JOIN play_activity "+
+		"ON rom.id = play_activity.rom_id "+
+		whereClause+
+		"GROUP BY rom.id "+
+		"HAVING play_time_total > 0 "+
+		"ORDER BY play_time_total DESC", args...)
 	if err != nil {
-		return nil, nil, 0
-	}
-	defer closeDB(db)
-
-	whereMod := ""
-	if searchFilter != "" {
-		whereMod = " WHERE " + searchFilter + " "
-	}
-
-	rows, err := db.Query("SELECT rom.id, rom.name, rom.file_path, " +
-						  "SUM(play_activity.play_time) AS play_time_total, " +
-						  "COUNT(play_activity.ROWID) AS play_count_total, " +
-						  "MIN(play_activity.created_at) AS first_played_at, " +
-						  "MAX(play_activity.created_at) AS last_played_at " +
-        				  "FROM rom " +
-						  "LEFT JOIN play_activity " +
-						  "ON rom.id = play_activity.rom_id " +
-						  whereMod +
-        				  "GROUP BY rom.id " +
-        				  "HAVING play_time_total > 0 " +
-        				  "ORDER BY play_time_total DESC")
+		return nil, nil, 0, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
 	defer rows.Close()
 
 	gamePlayMap := make(map[string][]models.PlayHistoryAggregate)
@@ -371,7 +669,7 @@ func GenerateCurrentGameStats(searchFilter string) (map[string][]models.PlayHist
 		var firstPlayedTime int
 		var lastPlayedTime 	int
 		if err := rows.Scan(&id, &name, &filePath, &playTimeTotal, &playCountTotal, &firstPlayedTime, &lastPlayedTime); err != nil {
-			logger.Error("Failed to load game tracker data", zap.Error(err))
+			return nil, nil, 0, fmt.Errorf("failed to load game tracker data: %w", err)
 		}
 
 		romName, romPath, multi := extractMultiDiscName(name, filePath)
@@ -398,9 +696,13 @@ func GenerateCurrentGameStats(searchFilter string) (map[string][]models.PlayHist
 		totalPlay = totalPlay + playTrack.PlayTimeTotal
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+
 	gamePlayMap = sortPlayMap(gamePlayMap, multiMap)
 
-	return gamePlayMap, consolePlayMap, totalPlay
+	return gamePlayMap, consolePlayMap, totalPlay, nil
 }
 
 func sortPlayMap(playMap map[string][]models.PlayHistoryAggregate, multiMap map[string]bool) map[string][]models.PlayHistoryAggregate {
@@ -421,10 +723,11 @@ func appendMultiDiscAggregate(existingList []models.PlayHistoryAggregate, newAgg
 			existingList[index] = models.PlayHistoryAggregate{
 				Id:					appendUniqueAggregateId(existingAggregate.Id, newAggregate.Id[0]),
 				Name: 				existingAggregate.Name,
+				Path:				existingAggregate.Path,
 				PlayTimeTotal:    	existingAggregate.PlayTimeTotal+newAggregate.PlayTimeTotal,
 				PlayCountTotal:    	existingAggregate.PlayCountTotal+newAggregate.PlayCountTotal,
 				FirstPlayedTime: 	minTime(existingAggregate.FirstPlayedTime, newAggregate.FirstPlayedTime),
-				LastPlayedTime:    	maxTime(existingAggregate.FirstPlayedTime, newAggregate.FirstPlayedTime),
+				LastPlayedTime:    	maxTime(existingAggregate.LastPlayedTime, newAggregate.LastPlayedTime),
 			}
 			return existingList
 		}
@@ -466,10 +769,14 @@ func extractMultiDiscName(romName string, filePath string) (string, string, bool
 }
 
 func extractPlayConsoleName(romFilePath string) string {
-	return strings.Split(romFilePath, "/")[0]
+	return strings.Split(unarchivedTrackerPath(romFilePath), "/")[0]
 }
 
 func extractItemConsoleName(gameItem shared.Item) string {
+	if isArchivedPath(gameItem.Path) {
+		return extractPlayConsoleName(trackerPathForRom(gameItem.Path))
+	}
+
 	pathSplit := strings.Split(gameItem.Path, "/")
 	if len(pathSplit) < 5 {
 		return ""
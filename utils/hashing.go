@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"archive/zip"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"hash/crc32"
+	"io"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const hashCacheFilename = "hash_cache.json"
+
+type hashCacheEntry struct {
+	FileSize int64            `json:"file_size"`
+	ModTime  int64            `json:"mod_time"`
+	Hashes   models.RomHashes `json:"hashes"`
+}
+
+var (
+	hashCacheMutex sync.Mutex
+	hashCache      map[string]hashCacheEntry
+	hashCacheDirty bool
+)
+
+// GetRomHashes returns the CRC32, MD5 and SHA1 of a ROM, hashing the largest file inside .zip archives.
+// Results are cached by path, size and modification time so repeated scans stay fast.
+func GetRomHashes(romPath string) (models.RomHashes, error) {
+	info, err := os.Stat(romPath)
+	if err != nil {
+		return models.RomHashes{}, fmt.Errorf("failed to stat ROM: %w", err)
+	}
+
+	if info.IsDir() {
+		return models.RomHashes{}, fmt.Errorf("%s is a directory", romPath)
+	}
+
+	if hashes, found := lookupHashCache(romPath, info); found {
+		return hashes, nil
+	}
+
+	var hashes models.RomHashes
+	if strings.EqualFold(filepath.Ext(romPath), ".zip") {
+		hashes, err = hashZippedRom(romPath)
+	} else {
+		hashes, err = hashFile(romPath)
+	}
+
+	if err != nil {
+		return models.RomHashes{}, err
+	}
+
+	hashCacheMutex.Lock()
+	hashCache[romPath] = hashCacheEntry{
+		FileSize: info.Size(),
+		ModTime:  info.ModTime().Unix(),
+		Hashes:   hashes,
+	}
+	hashCacheDirty = true
+	hashCacheMutex.Unlock()
+
+	return hashes, nil
+}
+
+// cachedRomHashes returns the hashes of a ROM only when they are already cached and the file has not changed since,
+// it never reads the ROM itself.
+func cachedRomHashes(romPath string) (models.RomHashes, bool) {
+	info, err := os.Stat(romPath)
+	if err != nil || info.IsDir() {
+		return models.RomHashes{}, false
+	}
+
+	return lookupHashCache(romPath, info)
+}
+
+func lookupHashCache(romPath string, info os.FileInfo) (models.RomHashes, bool) {
+	hashCacheMutex.Lock()
+	loadHashCache()
+	cached, found := hashCache[romPath]
+	hashCacheMutex.Unlock()
+
+	if found && cached.FileSize == info.Size() && cached.ModTime == info.ModTime().Unix() {
+		return cached.Hashes, true
+	}
+	return models.RomHashes{}, false
+}
+
+func hashFile(path string) (models.RomHashes, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return models.RomHashes{}, fmt.Errorf("failed to open ROM: %w", err)
+	}
+	defer file.Close()
+
+	return hashReader(file)
+}
+
+func hashZippedRom(path string) (models.RomHashes, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return models.RomHashes{}, fmt.Errorf("failed to open zip: %w", err)
+	}
+	defer reader.Close()
+
+	var largest *zip.File
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if largest == nil || file.UncompressedSize64 > largest.UncompressedSize64 {
+			largest = file
+		}
+	}
+
+	if largest == nil {
+		return models.RomHashes{}, fmt.Errorf("%s does not contain any files", path)
+	}
+
+	contents, err := largest.Open()
+	if err != nil {
+		return models.RomHashes{}, fmt.Errorf("failed to read %s from zip: %w", largest.Name, err)
+	}
+	defer contents.Close()
+
+	return hashReader(contents)
+}
+
+func hashReader(reader io.Reader) (models.RomHashes, error) {
+	crcHash := crc32.NewIEEE()
+	md5Hash := md5.New()
+	sha1Hash := sha1.New()
+
+	size, err := io.Copy(io.MultiWriter(crcHash, md5Hash, sha1Hash), reader)
+	if err != nil {
+		return models.RomHashes{}, fmt.Errorf("failed to hash ROM: %w", err)
+	}
+
+	return models.RomHashes{
+		Size:  size,
+		CRC32: hex.EncodeToString(crcHash.Sum(nil)),
+		MD5:   hex.EncodeToString(md5Hash.Sum(nil)),
+		SHA1:  hex.EncodeToString(sha1Hash.Sum(nil)),
+	}, nil
+}
+
+func loadHashCache() {
+	if hashCache != nil {
+		return
+	}
+
+	hashCache = make(map[string]hashCacheEntry)
+
+	data, err := os.ReadFile(filepath.Join(GetDataDirectory(), hashCacheFilename))
+	if err != nil {
+		return
+	}
+
+	if err := json.Unmarshal(data, &hashCache); err != nil {
+		common.GetLoggerInstance().Error("Failed to parse hash cache, starting fresh", zap.Error(err))
+		hashCache = make(map[string]hashCacheEntry)
+	}
+}
+
+// SaveHashCache persists hashes computed since the last save.
+func SaveHashCache() {
+	hashCacheMutex.Lock()
+	defer hashCacheMutex.Unlock()
+
+	if !hashCacheDirty {
+		return
+	}
+
+	for path := range hashCache {
+		if !DoesFileExists(path) {
+			delete(hashCache, path)
+		}
+	}
+
+	data, err := json.Marshal(hashCache)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(GetDataDirectory(), hashCacheFilename), data, defaultFilePerm)
+	}
+
+	if err != nil {
+		common.GetLoggerInstance().Error("Failed to save hash cache", zap.Error(err))
+		return
+	}
+
+	hashCacheDirty = false
+}
@@ -0,0 +1,389 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	journalFilename   = "journal.json"
+	trashDirectory    = "trash"
+	maxJournalBatches = 50
+
+	// DefaultTrashRetentionDays is how long deleted files are kept when Settings has no choice, -1 keeps them until emptied
+	DefaultTrashRetentionDays = 14
+)
+
+var (
+	journalMutex sync.Mutex
+	activeBatch  *models.JournalBatch
+	activeDepth  int
+)
+
+// BeginOperation starts journaling file and game tracker changes. Nested calls join the outermost operation,
+// so a bulk action and the per-game helpers it calls end up in a single undoable batch.
+func BeginOperation(description string) {
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+
+	activeDepth++
+	if activeBatch != nil {
+		return
+	}
+
+	activeBatch = &models.JournalBatch{
+		Id:          strconv.FormatInt(time.Now().UnixNano(), 10),
+		Description: description,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// CommitOperation closes the operation opened by BeginOperation and persists it once the outermost call returns.
+func CommitOperation() {
+	journalMutex.Lock()
+
+	if activeDepth == 0 {
+		journalMutex.Unlock()
+		return
+	}
+
+	activeDepth--
+	if activeDepth > 0 {
+		journalMutex.Unlock()
+		return
+	}
+
+	batch := activeBatch
+	activeBatch = nil
+	journalMutex.Unlock()
+
+	if batch == nil || len(batch.Entries) == 0 {
+		return
+	}
+
+	if err := appendJournalBatch(*batch); err != nil {
+		common.GetLoggerInstance().Error("Failed to write operation journal", zap.Error(err))
+	}
+}
+
+func recordJournalEntry(entry models.JournalEntry) {
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+
+	if activeBatch != nil {
+		activeBatch.Entries = append(activeBatch.Entries, entry)
+	}
+}
+
+func journalActive() bool {
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+
+	return activeBatch != nil
+}
+
+func activeBatchId() string {
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+
+	if activeBatch == nil {
+		return ""
+	}
+	return activeBatch.Id
+}
+
+// TrashFile moves a file or directory into the trash area of the active operation instead of unlinking it.
+func TrashFile(path string) error {
+	batchId := activeBatchId()
+	if batchId == "" {
+		batchId = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	destinationPath := filepath.Join(GetDataDirectory(), trashDirectory, batchId, strings.TrimPrefix(path, "/"))
+	if err := MoveFile(path, destinationPath); err != nil {
+		return fmt.Errorf("failed to move %s to trash: %w", path, err)
+	}
+
+	return nil
+}
+
+// journalFileWrite snapshots a small text file, such as a collection or the backlog, before it is rewritten so undo
+// can put the previous contents back or remove the file when it was created.
+func journalFileWrite(path string) {
+	if !journalActive() {
+		return
+	}
+
+	previous, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		recordJournalEntry(models.JournalEntry{
+			Type:    models.JournalEntryFileSnapshot,
+			Source:  path,
+			Created: true,
+		})
+		return
+	} else if err != nil {
+		common.GetLoggerInstance().Error("Failed to snapshot file", zap.String("file", path), zap.Error(err))
+		return
+	}
+
+	recordJournalEntry(models.JournalEntry{
+		Type:     models.JournalEntryFileSnapshot,
+		Source:   path,
+		Contents: string(previous),
+	})
+}
+
+func GetJournal() ([]models.JournalBatch, error) {
+	journalPath := filepath.Join(GetDataDirectory(), journalFilename)
+
+	data, err := os.ReadFile(journalPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var batches []models.JournalBatch
+	if err := decoder.Decode(&batches); err != nil {
+		return nil, fmt.Errorf("failed to parse journal: %w", err)
+	}
+
+	return batches, nil
+}
+
+func saveJournal(batches []models.JournalBatch) error {
+	data, err := json.MarshalIndent(batches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode journal: %w", err)
+	}
+
+	journalPath := filepath.Join(GetDataDirectory(), journalFilename)
+	if err := os.WriteFile(journalPath, data, defaultFilePerm); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	return nil
+}
+
+func appendJournalBatch(batch models.JournalBatch) error {
+	batches, err := GetJournal()
+	if err != nil {
+		return err
+	}
+
+	batches = append(batches, batch)
+
+	if len(batches) > maxJournalBatches {
+		expired := batches[:len(batches)-maxJournalBatches]
+		batches = batches[len(batches)-maxJournalBatches:]
+
+		for _, old := range expired {
+			purgeTrash(old.Id)
+		}
+	}
+
+	return saveJournal(batches)
+}
+
+func purgeTrash(batchId string) {
+	trashPath := filepath.Join(GetDataDirectory(), trashDirectory, batchId)
+	if err := os.RemoveAll(trashPath); err != nil {
+		common.GetLoggerInstance().Error("Failed to purge trash", zap.String("path", trashPath), zap.Error(err))
+	}
+}
+
+// batchUsesTrash reports whether undoing the batch needs files that are still in its trash folder.
+func batchUsesTrash(batch models.JournalBatch) bool {
+	if batch.Undone {
+		return false
+	}
+
+	trashPath := filepath.Join(GetDataDirectory(), trashDirectory, batch.Id) + string(filepath.Separator)
+	for _, entry := range batch.Entries {
+		if entry.Type == models.JournalEntryMove && strings.HasPrefix(entry.Destination, trashPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrashSize is the space the deleted files waiting in the trash take up.
+func TrashSize() int64 {
+	return directorySize(filepath.Join(GetDataDirectory(), trashDirectory))
+}
+
+// EmptyTrash permanently deletes everything in the trash and drops the operations that can no longer be undone
+// without it. It returns the space freed.
+func EmptyTrash() (int64, error) {
+	return purgeTrashWhere(func(models.JournalBatch) bool { return true })
+}
+
+// PurgeExpiredTrash permanently deletes the trash of operations older than retentionDays and drops them from the
+// journal, a negative retention keeps the trash until it is emptied. It returns the space freed.
+func PurgeExpiredTrash(retentionDays int, now time.Time) (int64, error) {
+	if retentionDays == 0 {
+		retentionDays = DefaultTrashRetentionDays
+	}
+	if retentionDays < 0 {
+		return 0, nil
+	}
+
+	cutoff := now.AddDate(0, 0, -retentionDays)
+	return purgeTrashWhere(func(batch models.JournalBatch) bool {
+		return batch.CreatedAt.Before(cutoff)
+	})
+}
+
+func purgeTrashWhere(expired func(models.JournalBatch) bool) (int64, error) {
+	batches, err := GetJournal()
+	if err != nil {
+		return 0, err
+	}
+
+	trashRoot := filepath.Join(GetDataDirectory(), trashDirectory)
+	sizeBefore := directorySize(trashRoot)
+
+	var kept []models.JournalBatch
+	for _, batch := range batches {
+		if batchUsesTrash(batch) && expired(batch) {
+			purgeTrash(batch.Id)
+			continue
+		}
+		kept = append(kept, batch)
+	}
+
+	if len(kept) != len(batches) {
+		if err := saveJournal(kept); err != nil {
+			return 0, err
+		}
+	}
+
+	// trash left behind by operations the journal no longer knows about
+	entries, err := os.ReadDir(trashRoot)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read trash: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == activeBatchId() {
+			continue
+		}
+		if !slices.ContainsFunc(kept, func(batch models.JournalBatch) bool { return batch.Id == entry.Name() && batchUsesTrash(batch) }) {
+			purgeTrash(entry.Name())
+		}
+	}
+
+	return sizeBefore - directorySize(trashRoot), nil
+}
+
+// LastUndoableOperation returns the most recent batch that has not been undone yet.
+func LastUndoableOperation() (models.JournalBatch, bool) {
+	batches, err := GetJournal()
+	if err != nil {
+		return models.JournalBatch{}, false
+	}
+
+	for i := len(batches) - 1; i >= 0; i-- {
+		if !batches[i].Undone {
+			return batches[i], true
+		}
+	}
+
+	return models.JournalBatch{}, false
+}
+
+// UndoOperation replays the inverse of every entry in the batch, newest first.
+func UndoOperation(batchId string) error {
+	logger := common.GetLoggerInstance()
+
+	batches, err := GetJournal()
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	for i, batch := range batches {
+		if batch.Id == batchId {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return fmt.Errorf("operation %s not found in journal", batchId)
+	}
+
+	if batches[index].Undone {
+		return fmt.Errorf("operation %s was already undone", batchId)
+	}
+
+	// entries that fail stay in the batch so the undo can be retried once whatever blocked them is fixed
+	var errs []error
+	var failed []models.JournalEntry
+	entries := batches[index].Entries
+	for i := len(entries) - 1; i >= 0; i-- {
+		if err := undoJournalEntry(entries[i]); err != nil {
+			logger.Error("Failed to undo journal entry", zap.String("type", entries[i].Type), zap.Error(err))
+			errs = append(errs, err)
+			failed = append([]models.JournalEntry{entries[i]}, failed...)
+		}
+	}
+
+	if len(failed) > 0 {
+		batches[index].Entries = failed
+	} else {
+		batches[index].Undone = true
+	}
+	if err := saveJournal(batches); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		purgeTrash(batchId)
+	}
+
+	return errors.Join(errs...)
+}
+
+func undoJournalEntry(entry models.JournalEntry) error {
+	switch entry.Type {
+	case models.JournalEntryMove:
+		if DoesFileExists(entry.Source) {
+			return fmt.Errorf("cannot restore %s, a file already exists there", entry.Source)
+		}
+		return MoveFile(entry.Destination, entry.Source)
+	case models.JournalEntryTrackerPath:
+		if !MigrateGameTrackerData(entry.OldName, entry.Destination, entry.Source) {
+			return fmt.Errorf("failed to revert game tracker path %s", entry.Destination)
+		}
+		return nil
+	case models.JournalEntryTrackerDelete:
+		return restoreGameTrackerSnapshot(entry.TrackerRows)
+	case models.JournalEntryTrackerMerge:
+		return undoGameTrackerMerge(entry)
+	case models.JournalEntryCopy:
+		return os.Remove(entry.Destination)
+	case models.JournalEntryFileSnapshot, models.JournalEntryCollection:
+		if entry.Created {
+			return os.Remove(entry.Source)
+		}
+		return os.WriteFile(entry.Source, []byte(entry.Contents), defaultFilePerm)
+	default:
+		return fmt.Errorf("unknown journal entry type %s", entry.Type)
+	}
+}
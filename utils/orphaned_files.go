@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"io/fs"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const orphanedFilesDirectory = ".Orphaned"
+
+// FindOrphanedFiles lists art in every .media folder and save files in every platform save folder that no ROM owns
+// anymore. ROMs sitting in an archive still own their art and saves. Save folders of platforms that have no ROM folder
+// at all are left alone.
+func FindOrphanedFiles() ([]models.OrphanedFile, error) {
+	orphans, err := findOrphanedArt()
+	if err != nil {
+		return nil, err
+	}
+
+	orphanedSaves, err := findOrphanedSaves()
+	if err != nil {
+		return nil, err
+	}
+
+	orphans = append(orphans, orphanedSaves...)
+	slices.SortFunc(orphans, func(a models.OrphanedFile, b models.OrphanedFile) int {
+		return cmp.Compare(b.Size, a.Size)
+	})
+
+	return orphans, nil
+}
+
+// mirroredRomDirectory is a folder relative to the ROM directory with the archive removed, so a platform folder and its
+// archived copies share the same key.
+func mirroredRomDirectory(directory string) string {
+	relativePath, err := filepath.Rel(GetRomDirectory(), directory)
+	if err != nil {
+		return directory
+	}
+
+	parts := strings.Split(relativePath, string(filepath.Separator))
+	if strings.HasPrefix(parts[0], ".") {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, string(filepath.Separator))
+}
+
+func findOrphanedArt() ([]models.OrphanedFile, error) {
+	root := GetRomDirectory()
+
+	owners := make(map[string]map[string]bool)
+	var mediaDirectories []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return nil
+		}
+
+		parent := filepath.Dir(path)
+		if d.IsDir() && d.Name() == ".media" {
+			mediaDirectories = append(mediaDirectories, path)
+			return filepath.SkipDir
+		}
+
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() && parent == root {
+				return nil
+			}
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// platform folders own the platform art in the top level .media
+		key := mirroredRomDirectory(parent)
+		if owners[key] == nil {
+			owners[key] = make(map[string]bool)
+		}
+		owners[key][d.Name()] = true
+		owners[key][removeFileExtension(d.Name())] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan ROM directory: %w", err)
+	}
+
+	var orphans []models.OrphanedFile
+	for _, mediaDirectory := range mediaDirectories {
+		romDirectory := filepath.Dir(mediaDirectory)
+		platform := platformDirectoryName(romDirectory)
+		if mirroredRomDirectory(romDirectory) == "" {
+			platform = "Platforms"
+		}
+		entries, err := os.ReadDir(mediaDirectory)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			if owners[mirroredRomDirectory(romDirectory)][removeFileExtension(entry.Name())] {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+
+			orphans = append(orphans, models.OrphanedFile{
+				Kind:     models.OrphanedFileKindArt,
+				Platform: platform,
+				Path:     filepath.Join(mediaDirectory, entry.Name()),
+				Size:     info.Size(),
+			})
+		}
+	}
+
+	return orphans, nil
+}
+
+func findOrphanedSaves() ([]models.OrphanedFile, error) {
+	roms, err := getLibraryRoms(true)
+	if err != nil {
+		return nil, err
+	}
+
+	platforms, err := os.ReadDir(GetRomDirectory())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ROM directory: %w", err)
+	}
+
+	bases := make(map[string][]string)
+	for _, platform := range platforms {
+		if tag := strings.ToUpper(cleanTag(extractTag(platform.Name()))); platform.IsDir() && tag != "" {
+			bases[tag] = nil
+		}
+	}
+
+	for _, rom := range roms {
+		tag := strings.ToUpper(cleanTag(extractTag(platformDirectoryName(rom.Path))))
+		bases[tag] = append(bases[tag], saveFileBases(shared.Item{Filename: filepath.Base(rom.Path), IsDirectory: rom.IsDirectory})...)
+		if item := libraryRomItem(rom); !item.IsDirectory {
+			bases[tag] = append(bases[tag], saveFileBases(item)...)
+		}
+	}
+
+	saveDirectories, err := os.ReadDir(GetSaveFileDirectory())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read save directory: %w", err)
+	}
+
+	var orphans []models.OrphanedFile
+	for _, saveDirectory := range saveDirectories {
+		owned, known := bases[strings.ToUpper(saveDirectory.Name())]
+		if !saveDirectory.IsDir() || !known {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(GetSaveFileDirectory(), saveDirectory.Name()))
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			if _, found := matchSaveFile(entry.Name(), owned); found {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+
+			orphans = append(orphans, models.OrphanedFile{
+				Kind:     models.OrphanedFileKindSave,
+				Platform: saveDirectory.Name(),
+				Path:     filepath.Join(GetSaveFileDirectory(), saveDirectory.Name(), entry.Name()),
+				Size:     info.Size(),
+			})
+		}
+	}
+
+	return orphans, nil
+}
+
+// DeleteOrphanedFiles trashes the files as one undoable operation and returns how many were removed.
+func DeleteOrphanedFiles(orphans []models.OrphanedFile) (int, error) {
+	BeginOperation(fmt.Sprintf("Delete %d orphaned files", len(orphans)))
+	defer CommitOperation()
+
+	var errs []error
+	deleted := 0
+	for _, orphan := range orphans {
+		if err := TrashFile(orphan.Path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+// ArchiveOrphanedFiles moves the files into .Orphaned inside the archive, art under its platform folder and saves under
+// their tag, where the scanner no longer picks them up.
+func ArchiveOrphanedFiles(orphans []models.OrphanedFile, archiveName string) (int, error) {
+	BeginOperation(fmt.Sprintf("Archive %d orphaned files", len(orphans)))
+	defer CommitOperation()
+
+	orphanedRoot := filepath.Join(GetArchiveRoot(archiveName), orphanedFilesDirectory)
+
+	var errs []error
+	archived := 0
+	for _, orphan := range orphans {
+		destinationPath := filepath.Join(orphanedRoot, "Saves", orphan.Platform, filepath.Base(orphan.Path))
+		if orphan.Kind == models.OrphanedFileKindArt {
+			destinationPath = filepath.Join(orphanedRoot, "Art", mirroredRomDirectory(filepath.Dir(filepath.Dir(orphan.Path))), filepath.Base(orphan.Path))
+		}
+
+		if err := MoveFile(orphan.Path, destinationPath); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		archived++
+	}
+
+	return archived, errors.Join(errs...)
+}
+
+// OrphanedFilesSize is the space deleting the files would free.
+func OrphanedFilesSize(orphans []models.OrphanedFile) int64 {
+	var size int64
+	for _, orphan := range orphans {
+		size += orphan.Size
+	}
+	return size
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"io/fs"
+	"nextui-game-manager/models"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	rehomeCandidateThreshold = 0.5
+	maxRehomeCandidates      = 10
+)
+
+// FindOrphanedHistory lists the tracker rows whose ROM is neither in the ROM directory nor in an archive.
+func FindOrphanedHistory() ([]models.OrphanedHistory, error) {
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT rom.id, rom.name, rom.file_path, " +
+		"COALESCE(SUM(play_activity.play_time), 0), " +
+		"COUNT(play_activity.ROWID), " +
+		"COALESCE(MAX(play_activity.created_at), 0) " +
+		"FROM rom " +
+		"LEFT JOIN play_activity ON rom.id = play_activity.rom_id " +
+		"GROUP BY rom.id " +
+		"ORDER BY rom.file_path")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+	defer rows.Close()
+
+	archives, _ := GetArchiveFileListBasic()
+
+	var orphans []models.OrphanedHistory
+	for rows.Next() {
+		var orphan models.OrphanedHistory
+		var lastPlayed int64
+		if err := rows.Scan(&orphan.RomId, &orphan.Name, &orphan.TrackerPath, &orphan.PlayTime, &orphan.PlayCount, &lastPlayed); err != nil {
+			return nil, fmt.Errorf("failed to load game tracker data: %w", err)
+		}
+		orphan.LastPlayed = time.Unix(lastPlayed, 0)
+
+		if trackerPathExists(orphan.TrackerPath, archives) {
+			continue
+		}
+
+		orphans = append(orphans, orphan)
+	}
+
+	return orphans, rows.Err()
+}
+
+func trackerPathExists(trackerPath string, archives []string) bool {
+	if DoesFileExists(filepath.Join(GetRomDirectory(), trackerPath)) {
+		return true
+	}
+
+	return slices.ContainsFunc(archives, func(archive string) bool {
+		return DoesFileExists(filepath.Join(GetArchiveRoot(archive), trackerPath))
+	})
+}
+
+// FindRehomeCandidates suggests current ROMs for an orphan, looking only at folders with the same platform tag.
+func FindRehomeCandidates(orphan models.OrphanedHistory) ([]models.RehomeCandidate, error) {
+	romDirectories, err := GetRomDirectories()
+	if err != nil {
+		return nil, err
+	}
+
+	tracked, err := trackedPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	console := extractPlayConsoleName(orphan.TrackerPath)
+	platformTag := extractTag(console)
+	orphanName := strings.ToLower(removeFileExtension(filepath.Base(orphan.TrackerPath)))
+
+	var candidates []models.RehomeCandidate
+	for _, romDirectory := range romDirectories {
+		directoryName := filepath.Base(romDirectory.Path)
+		if directoryName != console && (platformTag == "" || extractTag(directoryName) != platformTag) {
+			continue
+		}
+
+		_ = filepath.WalkDir(romDirectory.Path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			candidateName := strings.ToLower(removeFileExtension(d.Name()))
+			score := titleSimilarity(orphanName, candidateName)
+			if candidateName == orphanName {
+				score = 1
+			}
+
+			if score < rehomeCandidateThreshold {
+				return nil
+			}
+
+			trackerPath := trackerPathForRom(path)
+			candidates = append(candidates, models.RehomeCandidate{
+				Path:        path,
+				TrackerPath: trackerPath,
+				Score:       score,
+				HasHistory:  tracked[trackerPath],
+			})
+			return nil
+		})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b models.RehomeCandidate) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	if len(candidates) > maxRehomeCandidates {
+		candidates = candidates[:maxRehomeCandidates]
+	}
+
+	return candidates, nil
+}
+
+func trackedPaths() (map[string]bool, error) {
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT file_path FROM rom")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+	defer rows.Close()
+
+	paths := make(map[string]bool)
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to load game tracker data: %w", err)
+		}
+		paths[path] = true
+	}
+
+	return paths, rows.Err()
+}
+
+// RehomeHistory points an orphan at a current ROM. When that ROM already has history of its own
+// the orphan's sessions are merged into it, otherwise the orphan's row simply takes the new path.
+func RehomeHistory(orphan models.OrphanedHistory, candidate models.RehomeCandidate) error {
+	logger := common.GetLoggerInstance()
+
+	logger.Info("Rehoming play history",
+		zap.String("from", orphan.TrackerPath),
+		zap.String("to", candidate.TrackerPath),
+		zap.Bool("merge", candidate.HasHistory))
+
+	if candidate.HasHistory {
+		return mergeGameTrackerHistory(orphan.TrackerPath, candidate.TrackerPath)
+	}
+
+	if !MigrateGameTrackerData(removeFileExtension(filepath.Base(candidate.Path)), orphan.TrackerPath, candidate.TrackerPath) {
+		return fmt.Errorf("failed to move play history of %s", orphan.Name)
+	}
+
+	return nil
+}
+
+func DeleteOrphanedHistory(orphan models.OrphanedHistory) error {
+	if !clearGameTrackerPath(orphan.TrackerPath) {
+		return fmt.Errorf("failed to delete play history of %s", orphan.Name)
+	}
+	return nil
+}
+
+// DeletePlayHistory removes every tracker row of a play history aggregate, which covers all discs of a multi-disc game.
+func DeletePlayHistory(aggregate models.PlayHistoryAggregate) error {
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, romId := range aggregate.Id {
+		var trackerPath string
+		if err := db.QueryRow("SELECT file_path FROM rom WHERE id = ?", romId).Scan(&trackerPath); err != nil {
+			lastErr = fmt.Errorf("failed to find rom %d: %w", romId, err)
+			continue
+		}
+
+		if !clearGameTrackerPath(trackerPath) {
+			lastErr = fmt.Errorf("failed to delete play history of %s", trackerPath)
+		}
+	}
+
+	return lastErr
+}
@@ -0,0 +1,258 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"nextui-game-manager/models"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	statisticsChartFilename = "play_statistics.png"
+	chartWidth              = 512
+	chartHeight             = 384
+	chartScale              = 2
+	chartMargin             = 8
+	chartLineHeight         = 15
+	chartGlyphWidth         = 7
+	heatmapLeft             = 40
+	heatmapTop              = 72
+	heatmapCellWidth        = 18
+	heatmapCellHeight       = 18
+)
+
+var (
+	chartBackground = color.RGBA{R: 0x10, G: 0x10, B: 0x10, A: 0xff}
+	chartText       = color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+	chartDimText    = color.RGBA{R: 0x90, G: 0x90, B: 0x90, A: 0xff}
+	chartEmptyCell  = color.RGBA{R: 0x26, G: 0x26, B: 0x26, A: 0xff}
+	chartAccent     = color.RGBA{R: 0x3c, G: 0xd0, B: 0x8c, A: 0xff}
+	weekdayLabels   = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+)
+
+// chartCanvas draws text with the fixed 7x13 font at a low resolution, the finished chart
+// is scaled up with nearest neighbour so the text stays crisp on the device screen.
+// Images are placed after scaling so box art keeps its full resolution.
+type chartCanvas struct {
+	img    *image.RGBA
+	face   font.Face
+	images []chartImage
+}
+
+type chartImage struct {
+	bounds image.Rectangle
+	path   string
+}
+
+func newChartCanvas() *chartCanvas {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: chartBackground}, image.Point{}, draw.Src)
+
+	return &chartCanvas{img: img, face: basicfont.Face7x13}
+}
+
+func (cc *chartCanvas) text(x, baseline int, s string, c color.Color) {
+	drawer := font.Drawer{
+		Dst:  cc.img,
+		Src:  image.NewUniform(c),
+		Face: cc.face,
+		Dot:  fixed.P(x, baseline),
+	}
+	drawer.DrawString(s)
+}
+
+func (cc *chartCanvas) rightText(right, baseline int, s string, c color.Color) {
+	cc.text(right-len(s)*chartGlyphWidth, baseline, s, c)
+}
+
+// largeText draws s magnified by scale, for headline numbers.
+func (cc *chartCanvas) largeText(x, baseline int, s string, c color.Color, scale int) {
+	width := len(s) * chartGlyphWidth
+	glyphs := image.NewRGBA(image.Rect(0, 0, width, 13))
+	drawer := font.Drawer{
+		Dst:  glyphs,
+		Src:  image.NewUniform(c),
+		Face: cc.face,
+		Dot:  fixed.P(0, 11),
+	}
+	drawer.DrawString(s)
+
+	scaled := imaging.Resize(glyphs, width*scale, 13*scale, imaging.NearestNeighbor)
+	top := baseline - 11*scale
+	draw.Draw(cc.img, image.Rect(x, top, x+width*scale, top+13*scale), scaled, image.Point{}, draw.Over)
+}
+
+func (cc *chartCanvas) rect(x, y, w, h int, c color.Color) {
+	draw.Draw(cc.img, image.Rect(x, y, x+w, y+h), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+// picture fits the image at path into the given box, centred. Missing or unreadable images are skipped.
+func (cc *chartCanvas) picture(x, y, w, h int, path string) {
+	cc.images = append(cc.images, chartImage{bounds: image.Rect(x, y, x+w, y+h), path: path})
+}
+
+func (cc *chartCanvas) save(path string) error {
+	scaled := imaging.Resize(cc.img, chartWidth*chartScale, chartHeight*chartScale, imaging.NearestNeighbor)
+
+	for _, placed := range cc.images {
+		src, err := imaging.Open(placed.path)
+		if err != nil {
+			continue
+		}
+
+		box := image.Rect(placed.bounds.Min.X*chartScale, placed.bounds.Min.Y*chartScale,
+			placed.bounds.Max.X*chartScale, placed.bounds.Max.Y*chartScale)
+		fitted := imaging.Fit(src, box.Dx(), box.Dy(), imaging.Lanczos)
+
+		offset := image.Pt((box.Dx()-fitted.Bounds().Dx())/2, (box.Dy()-fitted.Bounds().Dy())/2)
+		target := image.Rectangle{Min: box.Min.Add(offset), Max: box.Min.Add(offset).Add(fitted.Bounds().Size())}
+		draw.Draw(scaled, target, fitted, fitted.Bounds().Min, draw.Over)
+	}
+
+	if err := EnsureDirectoryExists(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	return imaging.Save(scaled, path)
+}
+
+// GetStatisticsChartPath is where the last rendered statistics chart lives.
+func GetStatisticsChartPath() string {
+	return filepath.Join(GetDataDirectory(), statisticsChartFilename)
+}
+
+// RenderPlayHistoryStatistics draws the statistics onto a single PNG sized for the device screen.
+func RenderPlayHistoryStatistics(stats models.PlayHistoryStatistics, title string, chartPath string) error {
+	cc := newChartCanvas()
+
+	cc.text(chartMargin, 16, truncateChartText(title, 36), chartText)
+	cc.rightText(chartWidth-chartMargin, 16, fmt.Sprintf("%.1fH in %d sessions", float64(stats.TotalPlayTime)/3600.0, stats.SessionCount), chartDimText)
+
+	streakText := fmt.Sprintf("Current Streak: %s", pluralDays(stats.Streaks.Current))
+	cc.text(chartMargin, 36, streakText, chartText)
+	longestText := fmt.Sprintf("Longest: %s", pluralDays(stats.Streaks.Longest))
+	if stats.Streaks.Longest > 0 {
+		longestText = fmt.Sprintf("%s (%s - %s)", longestText,
+			stats.Streaks.LongestStart.Format("Jan 2 2006"), stats.Streaks.LongestEnd.Format("Jan 2 2006"))
+	}
+	cc.rightText(chartWidth-chartMargin, 36, longestText, chartText)
+
+	drawHeatmap(cc, stats.Heatmap)
+
+	columnTop := heatmapTop + 7*heatmapCellHeight + 22
+	drawMonthlyTop(cc, chartMargin, columnTop, stats.MonthlyTop)
+	drawAbandoned(cc, chartWidth/2+chartMargin, columnTop, stats.Abandoned)
+
+	return cc.save(chartPath)
+}
+
+func drawHeatmap(cc *chartCanvas, heatmap [7][24]int) {
+	cc.text(chartMargin, 56, "When You Play", chartText)
+
+	maxSeconds := 0
+	for _, day := range heatmap {
+		for _, seconds := range day {
+			maxSeconds = max(maxSeconds, seconds)
+		}
+	}
+
+	cc.rightText(chartWidth-chartMargin, 56, "More", chartDimText)
+	legendLeft := chartWidth - chartMargin - 4*chartGlyphWidth - 4 - 5*12
+	for i := 0; i < 5; i++ {
+		cc.rect(legendLeft+i*12, 46, 10, 10, heatColor(float64(i)/4))
+	}
+	cc.rightText(legendLeft-4, 56, "Less", chartDimText)
+
+	for hour := 0; hour < 24; hour += 3 {
+		cc.text(heatmapLeft+hour*heatmapCellWidth, heatmapTop-4, fmt.Sprintf("%d", hour), chartDimText)
+	}
+
+	for day, hours := range heatmap {
+		top := heatmapTop + day*heatmapCellHeight
+		cc.text(chartMargin, top+heatmapCellHeight-5, weekdayLabels[day], chartDimText)
+
+		for hour, seconds := range hours {
+			intensity := 0.0
+			if maxSeconds > 0 {
+				intensity = math.Sqrt(float64(seconds) / float64(maxSeconds))
+			}
+			cc.rect(heatmapLeft+hour*heatmapCellWidth+1, top+1, heatmapCellWidth-2, heatmapCellHeight-2, heatColor(intensity))
+		}
+	}
+}
+
+// heatColor blends from an empty cell to the accent, any play at all gets a visible tint.
+func heatColor(intensity float64) color.RGBA {
+	if intensity <= 0 {
+		return chartEmptyCell
+	}
+
+	intensity = 0.2 + 0.8*min(1, intensity)
+	blend := func(from, to uint8) uint8 {
+		return uint8(float64(from) + (float64(to)-float64(from))*intensity)
+	}
+
+	return color.RGBA{
+		R: blend(chartEmptyCell.R, chartAccent.R),
+		G: blend(chartEmptyCell.G, chartAccent.G),
+		B: blend(chartEmptyCell.B, chartAccent.B),
+		A: 0xff,
+	}
+}
+
+func drawMonthlyTop(cc *chartCanvas, left, top int, monthlyTop []models.MonthlyTopGame) {
+	cc.text(left, top, "Most Played Per Month", chartText)
+
+	if len(monthlyTop) == 0 {
+		cc.text(left, top+chartLineHeight+4, "Nothing played recently", chartDimText)
+		return
+	}
+
+	for i, month := range monthlyTop {
+		baseline := top + (i+1)*chartLineHeight + 4
+		hours := fmt.Sprintf("%.1fH", float64(month.PlayTime)/3600.0)
+		cc.text(left, baseline, month.Month.Format("Jan"), chartDimText)
+		cc.text(left+4*chartGlyphWidth, baseline, truncateChartText(month.Name, 33-4-len(hours)), chartText)
+		cc.rightText(left+chartWidth/2-2*chartMargin, baseline, hours, chartDimText)
+	}
+}
+
+func drawAbandoned(cc *chartCanvas, left, top int, abandoned []models.AbandonedGame) {
+	cc.text(left, top, "Started But Abandoned", chartText)
+
+	if len(abandoned) == 0 {
+		cc.text(left, top+chartLineHeight+4, "No abandoned games!", chartDimText)
+		return
+	}
+
+	for i, game := range abandoned {
+		baseline := top + (i+1)*chartLineHeight + 4
+		detail := fmt.Sprintf("%.1fH %dx", float64(game.PlayTime)/3600.0, game.PlayCount)
+		cc.text(left, baseline, truncateChartText(game.Name, 33-len(detail)), chartText)
+		cc.rightText(chartWidth-chartMargin, baseline, detail, chartDimText)
+	}
+}
+
+func truncateChartText(s string, maxChars int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) <= maxChars {
+		return string(runes)
+	}
+
+	return strings.TrimSpace(string(runes[:max(0, maxChars-2)])) + ".."
+}
+
+func pluralDays(days int) string {
+	if days == 1 {
+		return "1 Day"
+	}
+	return fmt.Sprintf("%d Days", days)
+}
@@ -0,0 +1,451 @@
+package utils
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"io"
+	"maps"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PlayHistoryFormatJSON = "json"
+	PlayHistoryFormatCSV  = "csv"
+
+	playHistoryExportPrefix = "play_history"
+	playHistoryGamesSuffix  = "_games.csv"
+	playHistorySessionsCSV  = "_sessions.csv"
+)
+
+var playHistoryGamesHeader = []string{"console", "name", "file_path", "play_time_total", "play_count_total", "first_played_at", "last_played_at"}
+var playHistorySessionsHeader = []string{"console", "name", "file_path", "play_time", "created_at", "updated_at"}
+
+// ExportPlayHistory reads every rom row of the game tracker along with its play sessions.
+func ExportPlayHistory() (models.PlayHistoryExport, error) {
+	export := models.PlayHistoryExport{ExportedAt: time.Now()}
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return export, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return export, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	romRows, err := queryRowMaps(tx, "SELECT * FROM rom ORDER BY file_path")
+	if err != nil {
+		return export, fmt.Errorf("failed to read roms: %w", err)
+	}
+
+	games := make(map[string]*models.PlayHistoryExportGame, len(romRows))
+	var order []string
+	for _, row := range romRows {
+		id := fmt.Sprint(row["id"])
+		filePath := fmt.Sprint(row["file_path"])
+
+		rom := maps.Clone(row)
+		delete(rom, "id")
+
+		games[id] = &models.PlayHistoryExportGame{
+			Name:     fmt.Sprint(row["name"]),
+			FilePath: filePath,
+			Console:  extractPlayConsoleName(filePath),
+			Rom:      rom,
+		}
+		order = append(order, id)
+	}
+
+	rows, err := tx.Query("SELECT rom_id, play_time, created_at, updated_at FROM play_activity ORDER BY created_at")
+	if err != nil {
+		return export, fmt.Errorf("failed to read play activity: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var romID string
+		var session models.PlayHistoryExportSession
+		var updatedAt sql.NullInt64
+		if err := rows.Scan(&romID, &session.PlayTime, &session.CreatedAt, &updatedAt); err != nil {
+			return export, fmt.Errorf("failed to read play activity: %w", err)
+		}
+		session.UpdatedAt = updatedAt.Int64
+
+		game, found := games[romID]
+		if !found {
+			continue
+		}
+
+		if game.FirstPlayedAt == 0 || session.CreatedAt < game.FirstPlayedAt {
+			game.FirstPlayedAt = session.CreatedAt
+		}
+		if session.CreatedAt > game.LastPlayedAt {
+			game.LastPlayedAt = session.CreatedAt
+		}
+
+		game.PlayTimeTotal += session.PlayTime
+		game.PlayCountTotal++
+		game.Sessions = append(game.Sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return export, fmt.Errorf("failed to read play activity: %w", err)
+	}
+
+	for _, id := range order {
+		game := games[id]
+		if len(game.Sessions) == 0 {
+			continue
+		}
+
+		export.TotalPlayTime += game.PlayTimeTotal
+		export.Games = append(export.Games, *game)
+	}
+
+	return export, nil
+}
+
+func WritePlayHistoryJSON(w io.Writer, export models.PlayHistoryExport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// WritePlayHistoryGamesCSV writes one aggregated row per game.
+func WritePlayHistoryGamesCSV(w io.Writer, export models.PlayHistoryExport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(playHistoryGamesHeader); err != nil {
+		return err
+	}
+
+	for _, game := range export.Games {
+		err := writer.Write([]string{
+			game.Console,
+			game.Name,
+			game.FilePath,
+			strconv.Itoa(game.PlayTimeTotal),
+			strconv.Itoa(game.PlayCountTotal),
+			strconv.FormatInt(game.FirstPlayedAt, 10),
+			strconv.FormatInt(game.LastPlayedAt, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WritePlayHistorySessionsCSV writes one row per play session, which is what the importer reads back.
+func WritePlayHistorySessionsCSV(w io.Writer, export models.PlayHistoryExport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(playHistorySessionsHeader); err != nil {
+		return err
+	}
+
+	for _, game := range export.Games {
+		for _, session := range game.Sessions {
+			err := writer.Write([]string{
+				game.Console,
+				game.Name,
+				game.FilePath,
+				strconv.Itoa(session.PlayTime),
+				strconv.FormatInt(session.CreatedAt, 10),
+				strconv.FormatInt(session.UpdatedAt, 10),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// SavePlayHistoryExport writes the export into directory in each requested format and returns the files written.
+func SavePlayHistoryExport(export models.PlayHistoryExport, directory string, formats ...string) ([]string, error) {
+	if err := EnsureDirectoryExists(directory); err != nil {
+		return nil, err
+	}
+
+	base := filepath.Join(directory, fmt.Sprintf("%s_%s", playHistoryExportPrefix, export.ExportedAt.Format("20060102_150405")))
+
+	var written []string
+	writeFile := func(path string, write func(io.Writer, models.PlayHistoryExport) error) error {
+		var buffer bytes.Buffer
+		if err := write(&buffer, export); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+		}
+		if err := os.WriteFile(path, buffer.Bytes(), defaultFilePerm); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+		return nil
+	}
+
+	for _, format := range formats {
+		var err error
+		switch format {
+		case PlayHistoryFormatJSON:
+			err = writeFile(base+".json", WritePlayHistoryJSON)
+		case PlayHistoryFormatCSV:
+			if err = writeFile(base+playHistoryGamesSuffix, WritePlayHistoryGamesCSV); err == nil {
+				err = writeFile(base+playHistorySessionsCSV, WritePlayHistorySessionsCSV)
+			}
+		default:
+			err = fmt.Errorf("unknown play history format %s", format)
+		}
+
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// IsPlayHistoryImportFile reports whether a file can be read by ReadPlayHistoryExport.
+// The aggregated games CSV has no sessions, so only the JSON and sessions CSV qualify. Collection exports share the folder.
+func IsPlayHistoryImportFile(filename string) bool {
+	if IsCollectionExportFile(filename) {
+		return false
+	}
+
+	lower := strings.ToLower(filename)
+	return strings.HasSuffix(lower, ".json") || strings.HasSuffix(lower, playHistorySessionsCSV)
+}
+
+// ReadPlayHistoryExport loads a JSON export or a sessions CSV written by another device.
+func ReadPlayHistoryExport(path string) (models.PlayHistoryExport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.PlayHistoryExport{}, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return parsePlayHistorySessionsCSV(data)
+	}
+
+	var export models.PlayHistoryExport
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&export); err != nil {
+		return export, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	return export, nil
+}
+
+func parsePlayHistorySessionsCSV(data []byte) (models.PlayHistoryExport, error) {
+	var export models.PlayHistoryExport
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return export, fmt.Errorf("failed to parse sessions CSV: %w", err)
+	}
+
+	if len(records) == 0 {
+		return export, nil
+	}
+
+	columns := make(map[string]int)
+	for i, column := range records[0] {
+		columns[strings.TrimSpace(column)] = i
+	}
+
+	for _, required := range []string{"name", "file_path", "play_time", "created_at"} {
+		if _, found := columns[required]; !found {
+			return export, fmt.Errorf("sessions CSV is missing the %s column", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i, found := columns[column]; found && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	games := make(map[string]int)
+	for line, record := range records[1:] {
+		playTime, err := strconv.Atoi(field(record, "play_time"))
+		if err != nil {
+			return export, fmt.Errorf("invalid play_time on line %d: %w", line+2, err)
+		}
+
+		createdAt, err := strconv.ParseInt(field(record, "created_at"), 10, 64)
+		if err != nil {
+			return export, fmt.Errorf("invalid created_at on line %d: %w", line+2, err)
+		}
+
+		updatedAt, _ := strconv.ParseInt(field(record, "updated_at"), 10, 64)
+
+		filePath := field(record, "file_path")
+		index, found := games[filePath]
+		if !found {
+			index = len(export.Games)
+			games[filePath] = index
+			export.Games = append(export.Games, models.PlayHistoryExportGame{
+				Name:     field(record, "name"),
+				FilePath: filePath,
+				Console:  extractPlayConsoleName(filePath),
+			})
+		}
+
+		export.Games[index].Sessions = append(export.Games[index].Sessions, models.PlayHistoryExportSession{
+			PlayTime:  playTime,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		})
+	}
+
+	return export, nil
+}
+
+// normalizeTrackerPath maps a path from an export onto the ROM directory relative form the tracker stores.
+func normalizeTrackerPath(path string) string {
+	path = filepath.ToSlash(strings.TrimSpace(path))
+	path = strings.TrimPrefix(path, strings.TrimSuffix(common.RomDirectory, "/")+"/")
+	return strings.TrimPrefix(path, "/")
+}
+
+// ImportPlayHistory merges an export into the game tracker. ROMs are matched by path and sessions that
+// already exist with the same start time are skipped, so importing the same export twice changes nothing.
+func ImportPlayHistory(export models.PlayHistoryExport) (models.PlayHistoryImportResult, error) {
+	logger := common.GetLoggerInstance()
+	var result models.PlayHistoryImportResult
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return result, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return result, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, game := range export.Games {
+		filePath := normalizeTrackerPath(game.FilePath)
+		if filePath == "" || len(game.Sessions) == 0 {
+			continue
+		}
+
+		romID, err := findRomID(tx, filePath)
+		if err == sql.ErrNoRows {
+			romID, err = insertImportedRom(tx, game, filePath)
+			if err != nil {
+				return result, fmt.Errorf("failed to add %s: %w", filePath, err)
+			}
+			result.GamesAdded++
+		} else if err != nil {
+			return result, fmt.Errorf("failed to look up %s: %w", filePath, err)
+		} else {
+			result.GamesMerged++
+		}
+
+		existing, err := existingSessionStarts(tx, romID)
+		if err != nil {
+			return result, fmt.Errorf("failed to read sessions of %s: %w", filePath, err)
+		}
+
+		for _, session := range game.Sessions {
+			if existing[session.CreatedAt] {
+				result.SessionsSkipped++
+				continue
+			}
+
+			_, err := tx.Exec("INSERT INTO play_activity (rom_id, play_time, created_at, updated_at) VALUES (?, ?, ?, ?)",
+				romID, session.PlayTime, session.CreatedAt, session.UpdatedAt)
+			if err != nil {
+				return result, fmt.Errorf("failed to add session of %s: %w", filePath, err)
+			}
+
+			existing[session.CreatedAt] = true
+			result.SessionsAdded++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	logger.Info("Imported play history",
+		zap.Int("gamesAdded", result.GamesAdded),
+		zap.Int("gamesMerged", result.GamesMerged),
+		zap.Int("sessionsAdded", result.SessionsAdded),
+		zap.Int("sessionsSkipped", result.SessionsSkipped))
+
+	return result, nil
+}
+
+// insertImportedRom adds a rom row, keeping the other device's columns when the export carried them.
+func insertImportedRom(tx *sql.Tx, game models.PlayHistoryExportGame, filePath string) (string, error) {
+	columns, err := tableColumns(tx, "rom")
+	if err != nil {
+		return "", err
+	}
+
+	// another firmware version may have columns this database doesn't know about
+	row := make(map[string]interface{})
+	for column, value := range game.Rom {
+		if columns[column] && column != "id" {
+			row[column] = value
+		}
+	}
+	row["name"] = game.Name
+	row["file_path"] = filePath
+
+	if err := insertRowMap(tx, "rom", row); err != nil {
+		return "", err
+	}
+
+	return findRomID(tx, filePath)
+}
+
+func existingSessionStarts(tx *sql.Tx, romID string) (map[int64]bool, error) {
+	rows, err := tx.Query("SELECT created_at FROM play_activity WHERE rom_id = ?", romID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	starts := make(map[int64]bool)
+	for rows.Next() {
+		var createdAt int64
+		if err := rows.Scan(&createdAt); err != nil {
+			return nil, err
+		}
+		starts[createdAt] = true
+	}
+
+	return starts, rows.Err()
+}
+
+func tableColumns(tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := queryRowMaps(tx, "SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		columns[fmt.Sprint(row["name"])] = true
+	}
+	return columns, nil
+}
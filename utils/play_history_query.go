@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"nextui-game-manager/models"
+	"strings"
+	"time"
+)
+
+// playHistoryBucket is how GenFiltersList groups play time at each depth of the filter menu.
+type playHistoryBucket struct {
+	sqlFormat string
+	layout    string
+	next      func(time.Time) time.Time
+}
+
+var playHistoryBuckets = map[int]playHistoryBucket{
+	NoFilter: {
+		sqlFormat: "%Y.%m",
+		layout:    "2006.01",
+		next:      func(start time.Time) time.Time { return start.AddDate(0, 1, 0) },
+	},
+	YearMonth: {
+		sqlFormat: "%Y.%m.%d",
+		layout:    "2006.01.02",
+		next:      func(start time.Time) time.Time { return start.AddDate(0, 0, 1) },
+	},
+}
+
+// compilePlayHistoryQuery turns a query into a WHERE clause over play_activity joined with rom.
+// Every value is bound as a parameter; an empty query compiles to an empty clause.
+func compilePlayHistoryQuery(query models.PlayHistoryQuery) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if len(query.RomIds) > 0 {
+		placeholders := make([]string, len(query.RomIds))
+		for i, romId := range query.RomIds {
+			placeholders[i] = "?"
+			args = append(args, romId)
+		}
+		conditions = append(conditions, "play_activity.rom_id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if len(query.Consoles) > 0 {
+		consoleConditions := make([]string, len(query.Consoles))
+		for i, console := range query.Consoles {
+			prefix := console + "/"
+			// archived ROMs keep their platform folder one level down inside the archive
+			consoleConditions[i] = "(substr(rom.file_path, 1, ?) = ? OR (substr(rom.file_path, 1, 1) = '.' AND instr(rom.file_path, ?) = instr(rom.file_path, '/')))"
+			args = append(args, len(prefix), prefix, "/"+prefix)
+		}
+		conditions = append(conditions, "("+strings.Join(consoleConditions, " OR ")+")")
+	}
+
+	if !query.Start.IsZero() {
+		conditions = append(conditions, "play_activity.created_at >= ?")
+		args = append(args, query.Start.Unix())
+	}
+
+	if !query.End.IsZero() {
+		conditions = append(conditions, "play_activity.created_at < ?")
+		args = append(args, query.End.Unix())
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND ") + " ", args
+}
+
+// NarrowPlayHistoryQuery scopes a query further: date ranges are intersected and the
+// ROM ids or consoles of scope replace those of query when set.
+func NarrowPlayHistoryQuery(query models.PlayHistoryQuery, scope models.PlayHistoryQuery) models.PlayHistoryQuery {
+	if len(scope.RomIds) > 0 {
+		query.RomIds = scope.RomIds
+	}
+
+	if len(scope.Consoles) > 0 {
+		query.Consoles = scope.Consoles
+	}
+
+	if query.Start.IsZero() || scope.Start.After(query.Start) {
+		query.Start = scope.Start
+	}
+
+	if query.End.IsZero() || (!scope.End.IsZero() && scope.End.Before(query.End)) {
+		query.End = scope.End
+	}
+
+	return query
+}
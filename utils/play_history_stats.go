@@ -0,0 +1,241 @@
+package utils
+
+import (
+	"cmp"
+	"fmt"
+	"nextui-game-manager/models"
+	"slices"
+	"time"
+)
+
+const (
+	monthlyTopMonths      = 6
+	abandonedMaxSessions  = 3
+	abandonedAfterDays    = 60
+	maxAbandonedGames     = 5
+	statisticsDayLayout   = "2006-01-02"
+	statisticsMonthLayout = "2006.01"
+)
+
+type statisticsGame struct {
+	name       string
+	console    string
+	playTime   int
+	playCount  int
+	lastPlayed time.Time
+}
+
+// GeneratePlayHistoryStatistics computes streaks, the day of week by hour heatmap, the most played game
+// of each recent month and games that were started but dropped. now anchors the current streak and
+// what counts as long ago, the query scopes the sessions the same way the play history filters do.
+func GeneratePlayHistoryStatistics(query models.PlayHistoryQuery, now time.Time) (models.PlayHistoryStatistics, error) {
+	var stats models.PlayHistoryStatistics
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return stats, err
+	}
+
+	whereClause, args := compilePlayHistoryQuery(query)
+
+	rows, err := db.Query("SELECT rom.name, rom.file_path, play_activity.play_time, play_activity.created_at "+
+		"FROM rom "+
+		"JOIN play_activity "+
+		"ON rom.id = play_activity.rom_id "+
+		whereClause+
+		"ORDER BY play_activity.created_at", args...)
+	if err != nil {
+		return stats, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+	defer rows.Close()
+
+	playedDays := make(map[string]bool)
+	games := make(map[string]*statisticsGame)
+	monthly := make(map[string]map[string]int)
+
+	for rows.Next() {
+		var name string
+		var filePath string
+		var playTime int
+		var createdAt int64
+		if err := rows.Scan(&name, &filePath, &playTime, &createdAt); err != nil {
+			return stats, fmt.Errorf("failed to load game tracker data: %w", err)
+		}
+
+		if playTime <= 0 {
+			continue
+		}
+
+		start := time.Unix(createdAt, 0).In(now.Location())
+		gameName, _, _ := extractMultiDiscName(name, filePath)
+		console := extractPlayConsoleName(filePath)
+		key := console + "/" + gameName
+
+		stats.TotalPlayTime += playTime
+		stats.SessionCount++
+
+		playedDays[start.Format(statisticsDayLayout)] = true
+		addSessionToHeatmap(&stats.Heatmap, start, playTime)
+
+		game, ok := games[key]
+		if !ok {
+			game = &statisticsGame{name: gameName, console: console}
+			games[key] = game
+		}
+		game.playTime += playTime
+		game.playCount++
+		if start.After(game.lastPlayed) {
+			game.lastPlayed = start
+		}
+
+		month := start.Format(statisticsMonthLayout)
+		if monthly[month] == nil {
+			monthly[month] = make(map[string]int)
+		}
+		monthly[month][key] += playTime
+	}
+
+	if err := rows.Err(); err != nil {
+		return stats, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+
+	stats.Streaks = calculatePlayStreaks(playedDays, now)
+	stats.MonthlyTop = calculateMonthlyTop(monthly, games, now)
+	stats.Abandoned = findAbandonedGames(games, now)
+
+	return stats, nil
+}
+
+// addSessionToHeatmap spreads a session over the hours it actually covered, so a three hour
+// evening session doesn't all land on the hour it started.
+func addSessionToHeatmap(heatmap *[7][24]int, start time.Time, playTime int) {
+	remaining := playTime
+	current := start
+	for remaining > 0 {
+		nextHour := time.Date(current.Year(), current.Month(), current.Day(), current.Hour()+1, 0, 0, 0, current.Location())
+		slice := max(1, min(remaining, int(nextHour.Sub(current).Seconds())))
+
+		heatmap[weekdayIndex(current)][current.Hour()] += slice
+
+		remaining -= slice
+		current = current.Add(time.Duration(slice) * time.Second)
+	}
+}
+
+// weekdayIndex starts the week on Monday.
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+func calculatePlayStreaks(playedDays map[string]bool, now time.Time) models.PlayStreaks {
+	var streaks models.PlayStreaks
+	if len(playedDays) == 0 {
+		return streaks
+	}
+
+	var days []time.Time
+	for day := range playedDays {
+		parsed, err := time.ParseInLocation(statisticsDayLayout, day, now.Location())
+		if err != nil {
+			continue
+		}
+		days = append(days, parsed)
+	}
+	slices.SortFunc(days, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	runStart := days[0]
+	runLength := 1
+	for i := 1; i <= len(days); i++ {
+		if i < len(days) && days[i].Equal(days[i-1].AddDate(0, 0, 1)) {
+			runLength++
+			continue
+		}
+
+		if runLength > streaks.Longest {
+			streaks.Longest = runLength
+			streaks.LongestStart = runStart
+			streaks.LongestEnd = days[i-1]
+		}
+
+		if i < len(days) {
+			runStart = days[i]
+			runLength = 1
+		}
+	}
+
+	today, _ := time.ParseInLocation(statisticsDayLayout, now.Format(statisticsDayLayout), now.Location())
+	day := today
+	if !playedDays[day.Format(statisticsDayLayout)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for playedDays[day.Format(statisticsDayLayout)] {
+		streaks.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return streaks
+}
+
+func calculateMonthlyTop(monthly map[string]map[string]int, games map[string]*statisticsGame, now time.Time) []models.MonthlyTopGame {
+	var monthlyTop []models.MonthlyTopGame
+
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	for i := 0; i < monthlyTopMonths; i++ {
+		month := firstOfMonth.AddDate(0, -i, 0)
+		playTimes := monthly[month.Format(statisticsMonthLayout)]
+		if len(playTimes) == 0 {
+			continue
+		}
+
+		var topKey string
+		for key, playTime := range playTimes {
+			if topKey == "" || playTime > playTimes[topKey] || (playTime == playTimes[topKey] && key < topKey) {
+				topKey = key
+			}
+		}
+
+		monthlyTop = append(monthlyTop, models.MonthlyTopGame{
+			Month:    month,
+			Name:     games[topKey].name,
+			PlayTime: playTimes[topKey],
+		})
+	}
+
+	return monthlyTop
+}
+
+// findAbandonedGames picks games with only a handful of sessions that haven't been touched in a while,
+// most played first since those are the ones that were given a real chance.
+func findAbandonedGames(games map[string]*statisticsGame, now time.Time) []models.AbandonedGame {
+	cutoff := now.AddDate(0, 0, -abandonedAfterDays)
+
+	var abandoned []models.AbandonedGame
+	for _, game := range games {
+		if game.playCount > abandonedMaxSessions || game.lastPlayed.After(cutoff) {
+			continue
+		}
+
+		abandoned = append(abandoned, models.AbandonedGame{
+			Name:       game.name,
+			Console:    game.console,
+			PlayTime:   game.playTime,
+			PlayCount:  game.playCount,
+			LastPlayed: game.lastPlayed,
+		})
+	}
+
+	slices.SortFunc(abandoned, func(a, b models.AbandonedGame) int {
+		if a.PlayTime != b.PlayTime {
+			return cmp.Compare(b.PlayTime, a.PlayTime)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	if len(abandoned) > maxAbandonedGames {
+		abandoned = abandoned[:maxAbandonedGames]
+	}
+
+	return abandoned
+}
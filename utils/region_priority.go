@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var DefaultRegionPriority = []string{"USA", "World", "Europe", "Japan"}
+
+var (
+	romTagPattern      = regexp.MustCompile(`\(([^)]+)\)`)
+	revisionPattern    = regexp.MustCompile(`^(?:Rev\s*([0-9A-Z.]+)|v([0-9][0-9A-Za-z.]*))$`)
+	digitsPattern      = regexp.MustCompile(`\d+`)
+	preReleasePattern  = regexp.MustCompile(`^(?:Beta|Proto|Prototype|Alpha|Demo|Sample|Preview|Kiosk)(?:\s.*)?$`)
+	unlicensedPattern  = regexp.MustCompile(`^(?:Unl|Pirate|Hack)$`)
+	badDumpFlagPattern = regexp.MustCompile(`\[(?:b|h|o|t|f)[0-9]*[^\]]*\]`)
+)
+
+type variantRank struct {
+	penalty  int
+	region   int
+	revision string
+}
+
+// rankVariant scores a ROM filename: retail beats unlicensed beats pre-release, then region priority, then newest revision.
+func rankVariant(filename string, priority []string) variantRank {
+	rank := variantRank{region: len(priority)}
+
+	if badDumpFlagPattern.MatchString(filename) {
+		rank.penalty += 8
+	}
+
+	for _, match := range romTagPattern.FindAllStringSubmatch(removeFileExtension(filename), -1) {
+		tag := strings.TrimSpace(match[1])
+
+		switch {
+		case preReleasePattern.MatchString(tag):
+			rank.penalty += 4
+		case unlicensedPattern.MatchString(tag):
+			rank.penalty += 2
+		case revisionPattern.MatchString(tag):
+			rank.revision = digitsPattern.ReplaceAllStringFunc(tag, func(digits string) string {
+				return fmt.Sprintf("%06s", digits)
+			})
+		default:
+			for _, region := range strings.Split(tag, ",") {
+				if index := slices.Index(priority, strings.TrimSpace(region)); index != -1 && index < rank.region {
+					rank.region = index
+				}
+			}
+		}
+	}
+
+	return rank
+}
+
+func compareVariants(a, b string, priority []string) int {
+	rankA := rankVariant(filepath.Base(a), priority)
+	rankB := rankVariant(filepath.Base(b), priority)
+
+	if rankA.penalty != rankB.penalty {
+		return rankA.penalty - rankB.penalty
+	}
+	if rankA.region != rankB.region {
+		return rankA.region - rankB.region
+	}
+	if c := strings.Compare(rankB.revision, rankA.revision); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// ProposeOneGameOneRom groups ROMs by title and keeps the variant that ranks best for the region priority.
+// When games is empty every ROM on the platform is considered.
+func ProposeOneGameOneRom(romDirectory shared.RomDirectory, games shared.Items, priority []string) ([]models.OneGameOneRomProposal, error) {
+	if len(priority) == 0 {
+		priority = DefaultRegionPriority
+	}
+
+	var paths []string
+	if len(games) == 0 {
+		roms, err := walkRoms(romDirectory.Path, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, rom := range roms {
+			paths = append(paths, rom.Path)
+		}
+	} else {
+		companions := make(map[string]map[string]bool)
+		for _, game := range games {
+			if game.IsDirectory && !game.IsMultiDiscDirectory && !game.IsSelfContainedDirectory {
+				continue
+			}
+
+			directory := filepath.Dir(game.Path)
+			if companions[directory] == nil {
+				companions[directory] = directoryCompanions(directory)
+			}
+			if companions[directory][filepath.Base(game.Path)] {
+				continue
+			}
+
+			paths = append(paths, game.Path)
+		}
+	}
+
+	// the discs and tracks of a game are never variants of each other
+	byTitle := make(map[string][]string)
+	var titles []string
+	for _, path := range paths {
+		title := variantTitle(filepath.Base(path))
+		if _, found := byTitle[title]; !found {
+			titles = append(titles, title)
+		}
+		byTitle[title] = append(byTitle[title], path)
+	}
+
+	slices.Sort(titles)
+
+	var proposals []models.OneGameOneRomProposal
+	for _, title := range titles {
+		variants := byTitle[title]
+		if len(variants) < 2 {
+			continue
+		}
+
+		slices.SortFunc(variants, func(a, b string) int {
+			return compareVariants(a, b, priority)
+		})
+
+		proposals = append(proposals, models.OneGameOneRomProposal{
+			Title:   strings.TrimSpace(titleTagPattern.ReplaceAllString(removeFileExtension(filepath.Base(variants[0])), "")) + discTags(filepath.Base(variants[0])),
+			Keep:    variants[0],
+			Archive: variants[1:],
+		})
+	}
+
+	return proposals, nil
+}
+
+// ArchiveVariants archives the given ROM paths into the first archive folder, returning how many were archived.
+func ArchiveVariants(paths []string, collectionMap map[string][]models.Collection) (int, error) {
+	logger := common.GetLoggerInstance()
+
+	archives, err := GetArchiveFileList()
+	if err != nil {
+		return 0, err
+	}
+
+	BeginOperation(fmt.Sprintf("1 Game 1 ROM: Archive %d ROMs", len(paths)))
+	defer CommitOperation()
+
+	archived := 0
+	var lastErr error
+	for _, path := range paths {
+		game, romDirectory, err := ResolveRomPath(path)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if err := ArchiveRom(game, romDirectory, archives[0], collectionMap); err != nil {
+			logger.Error("Failed to archive variant", zap.String("path", path), zap.Error(err))
+			lastErr = err
+			continue
+		}
+
+		archived++
+	}
+
+	return archived, lastErr
+}
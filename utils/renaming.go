@@ -3,42 +3,13 @@ package utils
 import (
 	"fmt"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
-	"github.com/UncleJunVIP/nextui-pak-shared-functions/filebrowser"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
 	"go.uber.org/zap"
 	"nextui-game-manager/models"
 	"os"
 	"path/filepath"
-	"strings"
 )
 
-func renameSaveFile(oldFilename, newFilename string, romDirectory shared.RomDirectory) {
-	logger := common.GetLoggerInstance()
-
-	tag := cleanTag(romDirectory.Tag)
-
-	saveDir := filepath.Join(GetSaveFileDirectory(), tag)
-	fb := filebrowser.NewFileBrowser(logger)
-
-	if err := fb.CWD(saveDir, true); err != nil {
-		logger.Error("Failed to access save directory", zap.String("dir", saveDir), zap.Error(err))
-		return
-	}
-
-	saveFile := findSaveFile(fb.Items, oldFilename)
-	if saveFile.Filename == "" {
-		logger.Info("No save file found to rename")
-		return
-	}
-
-	ext := strings.ReplaceAll(saveFile.Filename, removeFileExtension(oldFilename), "")
-	newSavePath := filepath.Join(saveDir, newFilename+ext)
-
-	if err := MoveFile(saveFile.Path, newSavePath); err != nil {
-		logger.Error("Failed to rename save file", zap.Error(err))
-	}
-}
-
 func RenameCollection(collection models.Collection, name string) (models.Collection, error) {
 	logger := common.GetLoggerInstance()
 
@@ -49,20 +20,18 @@ func RenameCollection(collection models.Collection, name string) (models.Collect
 		return models.Collection{}, fmt.Errorf("failed to rename collection: %w", err)
 	}
 
+	renameCollectionSort(collection.DisplayName, name)
+
 	collection.DisplayName = name
 	collection.CollectionFile = newPath
 	return collection, nil
 }
 
-func renameCollectionEntries(game shared.Item, oldDisplayName string, romDirectory shared.RomDirectory, collectionMap map[string][]models.Collection) {
-	collections := findCollectionsContainingGame(collectionMap, game)
-	for _, collection := range collections {
-		updateCollectionGamePath(collection, oldDisplayName, game, romDirectory)
-		SaveCollection(collection)
-	}
+func renameCollectionEntries(game shared.Item, oldPath string, newPath string, collectionMap map[string][]models.Collection) {
+	syncCollectionEntries(collectionMap, collectionEntryForRom(game, oldPath), collectionEntryForRom(game, newPath))
 }
 
-func RenameRom(game shared.Item, newFilename string, romDirectory shared.RomDirectory) (string, error) {
+func RenameRom(game shared.Item, newFilename string, romDirectory shared.RomDirectory, collectionMap map[string][]models.Collection) (string, error) {
 	logger := common.GetLoggerInstance()
 
 	oldPath := filepath.Join(romDirectory.Path, game.Filename)
@@ -70,6 +39,9 @@ func RenameRom(game shared.Item, newFilename string, romDirectory shared.RomDire
 
 	logger.Debug("Renaming ROM", zap.String("from", oldPath), zap.String("to", newPath))
 
+	BeginOperation(fmt.Sprintf("Rename %s to %s", game.DisplayName, newFilename))
+	defer CommitOperation()
+
 	if err := MoveFile(oldPath, newPath); err != nil {
 		return "", fmt.Errorf("failed to rename ROM file: %w", err)
 	}
@@ -78,9 +50,11 @@ func RenameRom(game shared.Item, newFilename string, romDirectory shared.RomDire
 	renameAssociatedFile(game.Filename, newFilename, newPath, ".m3u")
 
 	updateGameTrackerForRename(game.Filename, newFilename, romDirectory, logger)
-	renameSaveFile(game.Filename, newFilename, romDirectory)
-	// renameCollectionEntries(game, game.Filename, romDirectory) TODO need to finish this functionality
+	renameSaveFiles(game, filepath.Base(newPath), romDirectory)
+	renameSaveStates(game, filepath.Base(newPath), romDirectory)
+	renameCollectionEntries(game, oldPath, newPath, collectionMap)
 	renameArtFile(game.Filename, newFilename, romDirectory, logger)
+	renameBacklogEntry(oldPath, newPath, newFilename)
 
 	return filepath.Base(newPath), nil
 }
@@ -0,0 +1,496 @@
+package utils
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"nextui-game-manager/models"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	sdCardDirectory            = "/mnt/SDCARD"
+	defaultSaveBackupDirectory = "/mnt/SDCARD/Backups/Saves/"
+	DefaultSaveBackupKeep      = 5
+	saveBackupPrefix           = "save_backup_"
+	saveBackupTimeFormat       = "20060102_150405"
+
+	saveBackupSavesFolder  = "Saves"
+	saveBackupStatesFolder = "States"
+)
+
+// saveStateCorePattern matches the <TAG>-<core> folders of the shared userdata folder, pak folders are lower case.
+var saveStateCorePattern = regexp.MustCompile(`^[A-Z0-9_]+-.+$`)
+
+// GetSaveBackupDirectory is the configured backup folder, or Backups/Saves on the SD card when none is set.
+func GetSaveBackupDirectory(configured string) string {
+	dir := configured
+	if dir == "" {
+		dir = defaultSaveBackupDirectory
+		if IsDev() {
+			dir = os.Getenv("SAVE_BACKUP_DIRECTORY")
+		}
+	}
+
+	_ = EnsureDirectoryExists(dir)
+	return dir
+}
+
+// SaveBackupDirectoryChoices lists the folders backups can be written to: Backups/Saves on the SD card ("") and on each
+// writable drive mounted under /mnt or /media, so the backups survive the SD card.
+func SaveBackupDirectoryChoices() []string {
+	choices := []string{""}
+
+	data, err := os.ReadFile("/proc/mounts")
+	if err != nil {
+		return choices
+	}
+
+	sdCard := sdCardDirectory
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || slices.Contains(strings.Split(fields[3], ","), "ro") {
+			continue
+		}
+
+		mountPoint := filepath.Clean(fields[1])
+		if !strings.HasPrefix(mountPoint, "/mnt/") && !strings.HasPrefix(mountPoint, "/media/") {
+			continue
+		}
+		if mountPoint == sdCard || strings.HasPrefix(mountPoint, sdCard+string(filepath.Separator)) {
+			continue
+		}
+
+		choice := filepath.Join(mountPoint, "Backups", "Saves") + string(filepath.Separator)
+		if !slices.Contains(choices, choice) {
+			choices = append(choices, choice)
+		}
+	}
+
+	return choices
+}
+
+// saveBackupSource pairs a file on the SD card with its path inside the zip.
+type saveBackupSource struct {
+	path  string
+	entry string
+	info  fs.FileInfo
+}
+
+// collectSaveBackupSources lists every save file and every state folder file, slot previews included.
+func collectSaveBackupSources() ([]saveBackupSource, error) {
+	var sources []saveBackupSource
+
+	collect := func(root string, folder string) error {
+		return filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && filePath == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+
+			if strings.HasPrefix(d.Name(), ".") && filePath != root {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			relativePath, _ := filepath.Rel(root, filePath)
+			sources = append(sources, saveBackupSource{
+				path:  filePath,
+				entry: path.Join(folder, filepath.ToSlash(relativePath)),
+				info:  info,
+			})
+			return nil
+		})
+	}
+
+	if err := collect(GetSaveFileDirectory(), saveBackupSavesFolder); err != nil {
+		return nil, fmt.Errorf("failed to read saves: %w", err)
+	}
+
+	stateRoot := GetSaveStateDirectory()
+	entries, err := os.ReadDir(stateRoot)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read save states: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !saveStateCorePattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		if err := collect(filepath.Join(stateRoot, entry.Name()), path.Join(saveBackupStatesFolder, entry.Name())); err != nil {
+			return nil, fmt.Errorf("failed to read save states: %w", err)
+		}
+	}
+
+	previews, _ := os.ReadDir(filepath.Join(stateRoot, saveStatePreviewDirectory))
+	for _, entry := range previews {
+		if !entry.IsDir() {
+			continue
+		}
+
+		previewRoot := filepath.Join(stateRoot, saveStatePreviewDirectory, entry.Name())
+		if err := collect(previewRoot, path.Join(saveBackupStatesFolder, saveStatePreviewDirectory, entry.Name())); err != nil {
+			return nil, fmt.Errorf("failed to read save state previews: %w", err)
+		}
+	}
+
+	return sources, nil
+}
+
+// CreateSaveBackup zips every save and save state into directory, verifies the zip and then removes the oldest backups
+// so only keep are left.
+func CreateSaveBackup(directory string, keep int) (models.SaveBackup, error) {
+	backup := models.SaveBackup{CreatedAt: time.Now()}
+
+	sources, err := collectSaveBackupSources()
+	if err != nil {
+		return backup, err
+	}
+
+	if len(sources) == 0 {
+		return backup, fmt.Errorf("no saves to back up")
+	}
+
+	if err := EnsureDirectoryExists(directory); err != nil {
+		return backup, err
+	}
+
+	backup.Path = filepath.Join(directory, saveBackupPrefix+backup.CreatedAt.Format(saveBackupTimeFormat)+".zip")
+	temporaryPath := backup.Path + ".tmp"
+
+	if err := writeSaveBackup(temporaryPath, sources); err != nil {
+		os.Remove(temporaryPath)
+		return backup, err
+	}
+
+	if err := verifySaveBackup(temporaryPath, sources); err != nil {
+		os.Remove(temporaryPath)
+		return backup, err
+	}
+
+	if err := os.Rename(temporaryPath, backup.Path); err != nil {
+		os.Remove(temporaryPath)
+		return backup, fmt.Errorf("failed to finish backup: %w", err)
+	}
+
+	if info, err := os.Stat(backup.Path); err == nil {
+		backup.Size = info.Size()
+	}
+
+	return backup, rotateSaveBackups(directory, keep)
+}
+
+func writeSaveBackup(zipPath string, sources []saveBackupSource) error {
+	file, err := os.Create(zipPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	for _, source := range sources {
+		header, err := zip.FileInfoHeader(source.info)
+		if err != nil {
+			return err
+		}
+		header.Name = source.entry
+		header.Method = zip.Deflate
+
+		entry, err := writer.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to backup: %w", source.entry, err)
+		}
+
+		if err := copyIntoBackup(entry, source.path); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return file.Sync()
+}
+
+func copyIntoBackup(entry io.Writer, sourcePath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", sourcePath, err)
+	}
+	defer source.Close()
+
+	if _, err := io.Copy(entry, source); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", sourcePath, err)
+	}
+	return nil
+}
+
+// verifySaveBackup reads the written zip back, the zip reader checks the CRC of every entry as it reaches the end of it.
+func verifySaveBackup(zipPath string, sources []saveBackupSource) error {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("backup verification failed: %w", err)
+	}
+	defer reader.Close()
+
+	if len(reader.File) != len(sources) {
+		return fmt.Errorf("backup verification failed: %d of %d files written", len(reader.File), len(sources))
+	}
+
+	for i, file := range reader.File {
+		if file.Name != sources[i].entry || int64(file.UncompressedSize64) != sources[i].info.Size() {
+			return fmt.Errorf("backup verification failed: %s does not match", file.Name)
+		}
+
+		entry, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("backup verification failed: %w", err)
+		}
+
+		_, err = io.Copy(io.Discard, entry)
+		entry.Close()
+		if err != nil {
+			return fmt.Errorf("backup verification failed for %s: %w", file.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func rotateSaveBackups(directory string, keep int) error {
+	if keep <= 0 {
+		keep = DefaultSaveBackupKeep
+	}
+
+	backups, err := ListSaveBackups(directory)
+	if err != nil || len(backups) <= keep {
+		return err
+	}
+
+	for _, backup := range backups[keep:] {
+		if err := os.Remove(backup.Path); err != nil {
+			return fmt.Errorf("failed to remove old backup %s: %w", filepath.Base(backup.Path), err)
+		}
+	}
+
+	return nil
+}
+
+// ListSaveBackups returns the backups in directory, newest first.
+func ListSaveBackups(directory string) ([]models.SaveBackup, error) {
+	entries, err := os.ReadDir(directory)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read backup folder %s: %w", directory, err)
+	}
+
+	var backups []models.SaveBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, saveBackupPrefix) || filepath.Ext(name) != ".zip" {
+			continue
+		}
+
+		createdAt, err := time.ParseInLocation(saveBackupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, saveBackupPrefix), ".zip"), time.Local)
+		if err != nil {
+			continue
+		}
+
+		backup := models.SaveBackup{Path: filepath.Join(directory, name), CreatedAt: createdAt}
+		if info, err := entry.Info(); err == nil {
+			backup.Size = info.Size()
+		}
+		backups = append(backups, backup)
+	}
+
+	slices.SortFunc(backups, func(a models.SaveBackup, b models.SaveBackup) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// SaveBackupDue reports whether the newest backup is at least intervalDays old, a zero interval turns automatic backups off.
+func SaveBackupDue(directory string, intervalDays int, now time.Time) bool {
+	if intervalDays <= 0 {
+		return false
+	}
+
+	backups, err := ListSaveBackups(directory)
+	if err != nil {
+		return false
+	}
+
+	return len(backups) == 0 || now.Sub(backups[0].CreatedAt) >= time.Duration(intervalDays)*24*time.Hour
+}
+
+// ReadSaveBackupGames groups the files of a backup by platform tag and the ROM filename they were saved under.
+func ReadSaveBackupGames(backup models.SaveBackup) ([]models.SaveBackupGame, error) {
+	reader, err := zip.OpenReader(backup.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer reader.Close()
+
+	var games []models.SaveBackupGame
+	index := make(map[string]int)
+	for _, file := range reader.File {
+		tag, name, found := saveBackupGameOf(file.Name)
+		if !found {
+			continue
+		}
+
+		key := strings.ToLower(tag + "/" + name)
+		if _, exists := index[key]; !exists {
+			index[key] = len(games)
+			games = append(games, models.SaveBackupGame{Tag: tag, Name: name})
+		}
+		games[index[key]].Files = append(games[index[key]].Files, file.Name)
+	}
+
+	slices.SortFunc(games, func(a models.SaveBackupGame, b models.SaveBackupGame) int {
+		if compared := strings.Compare(a.Tag, b.Tag); compared != 0 {
+			return compared
+		}
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return games, nil
+}
+
+// saveBackupGameOf works out which game a zip entry belongs to from Saves/<TAG>/<rom>.sav, States/<TAG>-<core>/<rom>.stN
+// or States/.minui/<TAG>/<rom>.N.bmp.
+func saveBackupGameOf(entry string) (string, string, bool) {
+	parts := strings.Split(entry, "/")
+
+	switch {
+	case len(parts) == 3 && parts[0] == saveBackupSavesFolder:
+		return parts[1], removeFileExtension(parts[2]), true
+	case len(parts) == 4 && parts[0] == saveBackupStatesFolder && parts[1] == saveStatePreviewDirectory:
+		name := removeFileExtension(parts[3])
+		if filepath.Ext(parts[3]) != ".txt" {
+			name = removeFileExtension(name)
+		}
+		return parts[2], name, true
+	case len(parts) == 3 && parts[0] == saveBackupStatesFolder:
+		if match := saveStatePattern.FindStringSubmatch(parts[2]); match != nil {
+			return strings.SplitN(parts[1], "-", 2)[0], match[1], true
+		}
+	}
+
+	return "", "", false
+}
+
+// RestoreSaveBackup extracts files from a backup, every file when files is empty. Files it replaces go to the trash so the
+// restore can be undone.
+func RestoreSaveBackup(backup models.SaveBackup, files []string) (int, error) {
+	BeginOperation(fmt.Sprintf("Restore saves from backup %s", backup.CreatedAt.Format(time.DateTime)))
+	defer CommitOperation()
+
+	reader, err := zip.OpenReader(backup.Path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer reader.Close()
+
+	restored := 0
+	for _, file := range reader.File {
+		if len(files) > 0 && !slices.Contains(files, file.Name) {
+			continue
+		}
+
+		destinationPath, err := saveBackupDestination(file.Name)
+		if err != nil {
+			return restored, err
+		}
+
+		if DoesFileExists(destinationPath) {
+			if err := TrashFile(destinationPath); err != nil {
+				return restored, err
+			}
+		}
+
+		if err := extractSaveBackupFile(file, destinationPath); err != nil {
+			return restored, err
+		}
+
+		recordJournalEntry(models.JournalEntry{
+			Type:        models.JournalEntryCopy,
+			Source:      backup.Path,
+			Destination: destinationPath,
+		})
+		restored++
+	}
+
+	return restored, nil
+}
+
+// saveBackupDestination maps a zip entry back onto the SD card, refusing entries that would land outside the save folders.
+func saveBackupDestination(entry string) (string, error) {
+	cleaned := path.Clean(entry)
+	folder, relativePath, _ := strings.Cut(cleaned, "/")
+	if relativePath == "" || strings.HasPrefix(relativePath, "../") || path.IsAbs(cleaned) {
+		return "", fmt.Errorf("unexpected file %s in backup", entry)
+	}
+
+	switch folder {
+	case saveBackupSavesFolder:
+		return filepath.Join(GetSaveFileDirectory(), filepath.FromSlash(relativePath)), nil
+	case saveBackupStatesFolder:
+		return filepath.Join(GetSaveStateDirectory(), filepath.FromSlash(relativePath)), nil
+	}
+
+	return "", fmt.Errorf("unexpected file %s in backup", entry)
+}
+
+func extractSaveBackupFile(file *zip.File, destinationPath string) error {
+	if err := EnsureDirectoryExists(filepath.Dir(destinationPath)); err != nil {
+		return err
+	}
+
+	source, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read %s from backup: %w", file.Name, err)
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, defaultFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", destinationPath, err)
+	}
+
+	if _, err := io.Copy(destination, source); err != nil {
+		destination.Close()
+		return fmt.Errorf("failed to restore %s: %w", file.Name, err)
+	}
+
+	if err := destination.Close(); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", file.Name, err)
+	}
+
+	_ = os.Chtimes(destinationPath, file.Modified, file.Modified)
+	return nil
+}
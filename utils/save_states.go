@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const saveStatePreviewDirectory = ".minui"
+
+var saveStatePattern = regexp.MustCompile(`^(.+)\.st([0-9])$`)
+
+// saveStateNames are the names NextUI keys the states of game by, multi-disc games use the .m3u inside their folder.
+func saveStateNames(game shared.Item) []string {
+	if game.IsDirectory {
+		return []string{game.Filename + ".m3u", game.Filename}
+	}
+	return []string{game.Filename}
+}
+
+// archivedSaveStateDirectory mirrors the shared userdata folder inside an archive so archived states stay out of NextUI's way.
+func archivedSaveStateDirectory(archiveRoot string) string {
+	return filepath.Join(archiveRoot, ".userdata", "shared")
+}
+
+// FindSaveStates lists the state slots of a game with their preview screenshots, ordered by slot.
+func FindSaveStates(game shared.Item, romDirectory shared.RomDirectory) ([]models.SaveState, error) {
+	return findSaveStatesIn(GetSaveStateDirectory(), cleanTag(romDirectory.Tag), game)
+}
+
+// findSaveStatesIn looks through every <tag>-<core> folder of root, a platform can have states from more than one core.
+func findSaveStatesIn(root string, tag string, game shared.Item) ([]models.SaveState, error) {
+	coreDirectories, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read save state directory %s: %w", root, err)
+	}
+
+	names := saveStateNames(game)
+
+	var states []models.SaveState
+	for _, coreDirectory := range coreDirectories {
+		if !coreDirectory.IsDir() || !strings.HasPrefix(strings.ToUpper(coreDirectory.Name()), strings.ToUpper(tag)+"-") {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(root, coreDirectory.Name()))
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			match := saveStatePattern.FindStringSubmatch(entry.Name())
+			if entry.IsDir() || match == nil || !slices.ContainsFunc(names, func(name string) bool {
+				return strings.EqualFold(name, match[1])
+			}) {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+
+			slot, _ := strconv.Atoi(match[2])
+			states = append(states, models.SaveState{
+				Slot:        slot,
+				Path:        filepath.Join(root, coreDirectory.Name(), entry.Name()),
+				PreviewPath: findSaveStatePreview(root, tag, match[1], slot),
+				Size:        info.Size(),
+				ModTime:     info.ModTime(),
+			})
+		}
+	}
+
+	slices.SortStableFunc(states, func(a models.SaveState, b models.SaveState) int {
+		return a.Slot - b.Slot
+	})
+
+	return states, nil
+}
+
+func findSaveStatePreview(root string, tag string, name string, slot int) string {
+	for _, extension := range []string{".bmp", ".png"} {
+		previewPath := filepath.Join(root, saveStatePreviewDirectory, tag, fmt.Sprintf("%s.%d%s", name, slot, extension))
+		if DoesFileExists(previewPath) {
+			return previewPath
+		}
+	}
+	return ""
+}
+
+func DeleteSaveState(game shared.Item, saveState models.SaveState) error {
+	BeginOperation(fmt.Sprintf("Delete save state slot %d of %s", saveState.Slot, game.DisplayName))
+	defer CommitOperation()
+
+	return trashSaveState(saveState)
+}
+
+// ClearSaveStates trashes every state slot of a game along with its preview and returns how many slots were removed.
+func ClearSaveStates(game shared.Item, romDirectory shared.RomDirectory) (int, error) {
+	BeginOperation(fmt.Sprintf("Clear save states of %s", game.DisplayName))
+	defer CommitOperation()
+
+	states, err := FindSaveStates(game, romDirectory)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, saveState := range states {
+		if err := trashSaveState(saveState); err != nil {
+			return i, err
+		}
+	}
+
+	return len(states), nil
+}
+
+func trashSaveState(saveState models.SaveState) error {
+	if err := TrashFile(saveState.Path); err != nil {
+		return err
+	}
+
+	if saveState.PreviewPath != "" {
+		return TrashFile(saveState.PreviewPath)
+	}
+	return nil
+}
+
+// moveSaveStates moves the states of game, their previews and the remembered slot from one shared userdata folder to
+// another, renaming them after newRomFilename.
+func moveSaveStates(game shared.Item, tag string, fromRoot string, toRoot string, newRomFilename string) {
+	logger := common.GetLoggerInstance()
+
+	states, err := findSaveStatesIn(fromRoot, tag, game)
+	if err != nil {
+		logger.Error("Failed to find save states", zap.Error(err))
+		return
+	}
+
+	movedNames := make(map[string]string)
+	for _, saveState := range states {
+		name := saveStatePattern.FindStringSubmatch(filepath.Base(saveState.Path))[1]
+		newName := newRomFilename + name[len(game.Filename):]
+		movedNames[name] = newName
+
+		coreDirectory := filepath.Base(filepath.Dir(saveState.Path))
+		destinationPath := filepath.Join(toRoot, coreDirectory, fmt.Sprintf("%s.st%d", newName, saveState.Slot))
+		if err := MoveFile(saveState.Path, destinationPath); err != nil {
+			logger.Error("Failed to move save state", zap.String("state", saveState.Path), zap.Error(err))
+			continue
+		}
+
+		if saveState.PreviewPath != "" {
+			previewPath := filepath.Join(toRoot, saveStatePreviewDirectory, tag,
+				fmt.Sprintf("%s.%d%s", newName, saveState.Slot, filepath.Ext(saveState.PreviewPath)))
+			if err := MoveFile(saveState.PreviewPath, previewPath); err != nil {
+				logger.Error("Failed to move save state preview", zap.String("preview", saveState.PreviewPath), zap.Error(err))
+			}
+		}
+	}
+
+	for name, newName := range movedNames {
+		slotPath := filepath.Join(fromRoot, saveStatePreviewDirectory, tag, name+".txt")
+		if !DoesFileExists(slotPath) {
+			continue
+		}
+
+		if err := MoveFile(slotPath, filepath.Join(toRoot, saveStatePreviewDirectory, tag, newName+".txt")); err != nil {
+			logger.Error("Failed to move save state slot", zap.String("slot", slotPath), zap.Error(err))
+		}
+	}
+}
+
+func renameSaveStates(game shared.Item, newRomFilename string, romDirectory shared.RomDirectory) {
+	root := GetSaveStateDirectory()
+	moveSaveStates(game, cleanTag(romDirectory.Tag), root, root, newRomFilename)
+}
+
+func archiveSaveStates(game shared.Item, romDirectory shared.RomDirectory, archiveName string) {
+	moveSaveStates(game, cleanTag(romDirectory.Tag), GetSaveStateDirectory(),
+		archivedSaveStateDirectory(GetArchiveRoot(archiveName)), game.Filename)
+}
+
+func restoreSaveStates(game shared.Item, romDirectory shared.RomDirectory, archive shared.RomDirectory) {
+	moveSaveStates(game, cleanTag(romDirectory.Tag), archivedSaveStateDirectory(archive.Path),
+		GetSaveStateDirectory(), game.Filename)
+}
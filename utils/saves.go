@@ -0,0 +1,296 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	saveSnapshotsDirectory = "save_snapshots"
+	saveSnapshotTimeFormat = "20060102_150405"
+)
+
+func saveDirectoryFor(romDirectory shared.RomDirectory) string {
+	return filepath.Join(GetSaveFileDirectory(), cleanTag(romDirectory.Tag))
+}
+
+// saveFileBases are the names a save of game can start with. Cores save as Game.gba.sav or Game.sav,
+// multi-disc games save under the .m3u inside their folder.
+func saveFileBases(game shared.Item) []string {
+	if game.IsDirectory {
+		return []string{game.Filename, game.Filename + ".m3u"}
+	}
+	return []string{game.Filename, removeFileExtension(game.Filename)}
+}
+
+// matchSaveFile returns the base a save file is named after, the save matches only when it is exactly a base plus one extension.
+func matchSaveFile(saveFilename string, bases []string) (string, bool) {
+	name := removeFileExtension(saveFilename)
+	for _, base := range bases {
+		if base != "" && strings.EqualFold(name, base) {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// archivedSaveDirectory mirrors the Saves folder inside an archive, one folder per tag, hidden like the archived states.
+func archivedSaveDirectory(archiveRoot string) string {
+	return filepath.Join(archiveRoot, ".Saves")
+}
+
+// FindSaveFiles lists the save files of a game, newest first.
+func FindSaveFiles(game shared.Item, romDirectory shared.RomDirectory) ([]models.SaveFile, error) {
+	return findSaveFilesIn(saveDirectoryFor(romDirectory), game)
+}
+
+func findSaveFilesIn(saveDirectory string, game shared.Item) ([]models.SaveFile, error) {
+	entries, err := os.ReadDir(saveDirectory)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read save directory %s: %w", saveDirectory, err)
+	}
+
+	bases := saveFileBases(game)
+
+	var saves []models.SaveFile
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		if _, found := matchSaveFile(entry.Name(), bases); !found {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		saves = append(saves, models.SaveFile{
+			Path:     filepath.Join(saveDirectory, entry.Name()),
+			Filename: entry.Name(),
+			Size:     info.Size(),
+			ModTime:  info.ModTime(),
+		})
+	}
+
+	slices.SortFunc(saves, func(a models.SaveFile, b models.SaveFile) int {
+		return b.ModTime.Compare(a.ModTime)
+	})
+
+	return saves, nil
+}
+
+// renameSaveFiles moves the saves of game along with a ROM renamed to newRomFilename, keeping each save's extension.
+func renameSaveFiles(game shared.Item, newRomFilename string, romDirectory shared.RomDirectory) {
+	logger := common.GetLoggerInstance()
+
+	saves, err := FindSaveFiles(game, romDirectory)
+	if err != nil {
+		logger.Error("Failed to access save directory", zap.Error(err))
+		return
+	}
+
+	if len(saves) == 0 {
+		logger.Info("No save file found to rename")
+		return
+	}
+
+	bases := saveFileBases(game)
+	newBases := saveFileBases(shared.Item{Filename: newRomFilename, IsDirectory: game.IsDirectory})
+
+	for _, save := range saves {
+		base, _ := matchSaveFile(save.Filename, bases)
+		newBase := newBases[slices.Index(bases, base)]
+		newSavePath := filepath.Join(filepath.Dir(save.Path), newBase+save.Filename[len(base):])
+
+		if err := MoveFile(save.Path, newSavePath); err != nil {
+			logger.Error("Failed to rename save file", zap.String("save", save.Filename), zap.Error(err))
+		}
+	}
+}
+
+func archiveSaveFiles(game shared.Item, romDirectory shared.RomDirectory, archiveName string) {
+	moveSaveFiles(game, saveDirectoryFor(romDirectory),
+		filepath.Join(archivedSaveDirectory(GetArchiveRoot(archiveName)), cleanTag(romDirectory.Tag)))
+}
+
+func restoreSaveFiles(game shared.Item, romDirectory shared.RomDirectory, archive shared.RomDirectory) {
+	moveSaveFiles(game, filepath.Join(archivedSaveDirectory(archive.Path), cleanTag(romDirectory.Tag)),
+		saveDirectoryFor(romDirectory))
+}
+
+func moveSaveFiles(game shared.Item, fromDirectory string, toDirectory string) {
+	logger := common.GetLoggerInstance()
+
+	saves, err := findSaveFilesIn(fromDirectory, game)
+	if err != nil {
+		logger.Error("Failed to access save directory", zap.Error(err))
+		return
+	}
+
+	for _, save := range saves {
+		destinationPath := filepath.Join(toDirectory, save.Filename)
+		if DoesFileExists(destinationPath) {
+			logger.Warn("Save file already exists, leaving it in place", zap.String("save", destinationPath))
+			continue
+		}
+
+		if err := MoveFile(save.Path, destinationPath); err != nil {
+			logger.Error("Failed to move save file", zap.String("save", save.Filename), zap.Error(err))
+		}
+	}
+}
+
+func saveSnapshotDirectoryFor(game shared.Item, romDirectory shared.RomDirectory) string {
+	return filepath.Join(GetDataDirectory(), saveSnapshotsDirectory, cleanTag(romDirectory.Tag), game.Filename)
+}
+
+// BackupSaves copies the current saves of a game into a new timestamped snapshot.
+func BackupSaves(game shared.Item, romDirectory shared.RomDirectory) (models.SaveSnapshot, error) {
+	BeginOperation(fmt.Sprintf("Back up saves of %s", game.DisplayName))
+	defer CommitOperation()
+
+	snapshot := models.SaveSnapshot{CreatedAt: time.Now()}
+
+	saves, err := FindSaveFiles(game, romDirectory)
+	if err != nil {
+		return snapshot, err
+	}
+
+	if len(saves) == 0 {
+		return snapshot, fmt.Errorf("no save files found for %s", game.DisplayName)
+	}
+
+	snapshot.Path = filepath.Join(saveSnapshotDirectoryFor(game, romDirectory), snapshot.CreatedAt.Format(saveSnapshotTimeFormat))
+
+	for _, save := range saves {
+		if err := CopyFile(save.Path, filepath.Join(snapshot.Path, save.Filename)); err != nil {
+			return snapshot, err
+		}
+		snapshot.Files = append(snapshot.Files, save)
+	}
+
+	return snapshot, nil
+}
+
+// ListSaveSnapshots returns the snapshots of a game, newest first.
+func ListSaveSnapshots(game shared.Item, romDirectory shared.RomDirectory) ([]models.SaveSnapshot, error) {
+	snapshotDirectory := saveSnapshotDirectoryFor(game, romDirectory)
+
+	entries, err := os.ReadDir(snapshotDirectory)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots %s: %w", snapshotDirectory, err)
+	}
+
+	var snapshots []models.SaveSnapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		createdAt, err := time.ParseInLocation(saveSnapshotTimeFormat, entry.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+
+		snapshot := models.SaveSnapshot{
+			Path:      filepath.Join(snapshotDirectory, entry.Name()),
+			CreatedAt: createdAt,
+		}
+
+		files, _ := os.ReadDir(snapshot.Path)
+		for _, file := range files {
+			info, err := file.Info()
+			if err != nil || file.IsDir() {
+				continue
+			}
+
+			snapshot.Files = append(snapshot.Files, models.SaveFile{
+				Path:     filepath.Join(snapshot.Path, file.Name()),
+				Filename: file.Name(),
+				Size:     info.Size(),
+				ModTime:  info.ModTime(),
+			})
+		}
+
+		if len(snapshot.Files) > 0 {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	slices.SortFunc(snapshots, func(a models.SaveSnapshot, b models.SaveSnapshot) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return snapshots, nil
+}
+
+// RestoreSaveSnapshot replaces the current saves of a game with the files of snapshot. The replaced saves go to the trash
+// so the restore can be undone.
+func RestoreSaveSnapshot(game shared.Item, romDirectory shared.RomDirectory, snapshot models.SaveSnapshot) error {
+	BeginOperation(fmt.Sprintf("Restore saves of %s from %s", game.DisplayName, snapshot.CreatedAt.Format(time.DateTime)))
+	defer CommitOperation()
+
+	saves, err := FindSaveFiles(game, romDirectory)
+	if err != nil {
+		return err
+	}
+
+	for _, save := range saves {
+		if err := TrashFile(save.Path); err != nil {
+			return err
+		}
+	}
+
+	saveDirectory := saveDirectoryFor(romDirectory)
+	for _, save := range snapshot.Files {
+		if err := CopyFile(save.Path, filepath.Join(saveDirectory, save.Filename)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExportSaves copies the saves of a game to Saves/<TAG> in the export folder and returns that folder.
+func ExportSaves(game shared.Item, romDirectory shared.RomDirectory) (string, error) {
+	exportDirectory := filepath.Join(GetExportDirectory(), "Saves", cleanTag(romDirectory.Tag))
+
+	saves, err := FindSaveFiles(game, romDirectory)
+	if err != nil {
+		return exportDirectory, err
+	}
+
+	if len(saves) == 0 {
+		return exportDirectory, fmt.Errorf("no save files found for %s", game.DisplayName)
+	}
+
+	for _, save := range saves {
+		if err := CopyFile(save.Path, filepath.Join(exportDirectory, save.Filename)); err != nil {
+			return exportDirectory, err
+		}
+	}
+
+	return exportDirectory, nil
+}
+
+func DeleteSaveFile(game shared.Item, save models.SaveFile) error {
+	BeginOperation(fmt.Sprintf("Delete save %s of %s", save.Filename, game.DisplayName))
+	defer CommitOperation()
+
+	return TrashFile(save.Path)
+}
@@ -0,0 +1,373 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// smartCollectionsFile lives next to config.yml, the rules are edited by hand like the rest of the settings.
+	smartCollectionsFile = "smart_collections.yml"
+
+	// generatedSmartCollectionsFilename lists the collections smart collections wrote, the only ones they may rewrite.
+	generatedSmartCollectionsFilename = "smart_collections_generated.json"
+)
+
+var relativeDatePattern = regexp.MustCompile(`^(\d+)\s*([dwmy])$`)
+
+type romActivity struct {
+	playTime   int
+	lastPlayed int64
+}
+
+// smartLibrary is everything the rules look at, gathered once so refreshing several collections walks the SD card once.
+type smartLibrary struct {
+	roms     []libraryRom
+	activity map[string]romActivity
+	backlog  map[string]string
+}
+
+type smartRuleSet struct {
+	rules        models.SmartCollectionRules
+	playedAfter  time.Time
+	playedBefore time.Time
+}
+
+func LoadSmartCollections() (models.SmartCollectionFile, error) {
+	var file models.SmartCollectionFile
+
+	data, err := os.ReadFile(smartCollectionsFile)
+	if os.IsNotExist(err) {
+		return file, nil
+	} else if err != nil {
+		return file, fmt.Errorf("reading %s: %w", smartCollectionsFile, err)
+	}
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("parsing %s: %w", smartCollectionsFile, err)
+	}
+
+	return file, nil
+}
+
+// RefreshSmartCollections rewrites the collection file of each smart collection, returning how many games each one matched.
+// A collection with broken rules, or named like a collection the user made, is skipped and reported without stopping the others.
+func RefreshSmartCollections(collections []models.SmartCollection, now time.Time) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(collections) == 0 {
+		return counts, nil
+	}
+
+	library, err := loadSmartLibrary()
+	if err != nil {
+		return counts, err
+	}
+
+	generated := loadGeneratedCollections(generatedSmartCollectionsFilename)
+	generatedBefore := len(generated)
+
+	var errs []error
+	for _, smartCollection := range collections {
+		name := strings.TrimSpace(smartCollection.Name)
+		if isUserCollection(name, generated) {
+			errs = append(errs, fmt.Errorf("%s: a collection with that name already exists", smartCollection.Name))
+			continue
+		}
+
+		count, err := materializeSmartCollection(smartCollection, library, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", smartCollection.Name, err))
+			continue
+		}
+		counts[smartCollection.Name] = count
+
+		if !slices.Contains(generated, name) {
+			generated = append(generated, name)
+		}
+	}
+
+	if len(generated) != generatedBefore {
+		saveGeneratedCollections(generatedSmartCollectionsFilename, generated)
+	}
+
+	return counts, errors.Join(errs...)
+}
+
+// LaunchSmartCollections lists the smart collections marked refresh_on_launch.
+func LaunchSmartCollections() []models.SmartCollection {
+	file, err := LoadSmartCollections()
+	if err != nil {
+		common.GetLoggerInstance().Error("Unable to load smart collections", zap.Error(err))
+		return nil
+	}
+
+	var launchCollections []models.SmartCollection
+	for _, smartCollection := range file.Collections {
+		if smartCollection.RefreshOnLaunch {
+			launchCollections = append(launchCollections, smartCollection)
+		}
+	}
+	return launchCollections
+}
+
+func loadSmartLibrary() (smartLibrary, error) {
+	roms, err := getLibraryRoms(false)
+	if err != nil {
+		return smartLibrary{}, err
+	}
+
+	backlog, err := LoadBacklog()
+	if err != nil {
+		return smartLibrary{}, err
+	}
+
+	statuses := make(map[string]string)
+	for _, entry := range backlog.Games {
+		statuses[entry.Path] = entry.Status
+	}
+
+	return smartLibrary{
+		roms:     roms,
+		activity: activityByTrackerPath(),
+		backlog:  statuses,
+	}, nil
+}
+
+func materializeSmartCollection(smartCollection models.SmartCollection, library smartLibrary, now time.Time) (int, error) {
+	name := strings.TrimSpace(smartCollection.Name)
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return 0, fmt.Errorf("invalid collection name %q", smartCollection.Name)
+	}
+
+	ruleSet, err := compileSmartRules(smartCollection.Rules, now)
+	if err != nil {
+		return 0, err
+	}
+
+	collection := models.Collection{
+		DisplayName:    name,
+		CollectionFile: filepath.Join(GetCollectionDirectory(), name+".txt"),
+	}
+
+	for _, rom := range library.roms {
+		if !ruleSet.matches(rom, library) {
+			continue
+		}
+
+		collection.Games = append(collection.Games, libraryRomItem(rom))
+	}
+
+	slices.SortFunc(collection.Games, func(g1 shared.Item, g2 shared.Item) int {
+		return strings.Compare(strings.ToLower(g1.DisplayName), strings.ToLower(g2.DisplayName))
+	})
+
+	if err := SaveCollection(collection); err != nil {
+		return 0, err
+	}
+
+	return len(collection.Games), nil
+}
+
+func compileSmartRules(rules models.SmartCollectionRules, now time.Time) (smartRuleSet, error) {
+	ruleSet := smartRuleSet{rules: rules}
+
+	var err error
+	if rules.PlayedAfter != "" {
+		if ruleSet.playedAfter, err = resolveSmartDate(rules.PlayedAfter, now); err != nil {
+			return ruleSet, fmt.Errorf("played_after: %w", err)
+		}
+	}
+
+	if rules.PlayedBefore != "" {
+		if ruleSet.playedBefore, err = resolveSmartDate(rules.PlayedBefore, now); err != nil {
+			return ruleSet, fmt.Errorf("played_before: %w", err)
+		}
+	}
+
+	return ruleSet, nil
+}
+
+// resolveSmartDate turns a rule date into the midnight it starts at.
+func resolveSmartDate(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch value {
+	case "today":
+		return today, nil
+	case "this_week":
+		return today.AddDate(0, 0, -weekdayIndex(today)), nil
+	case "this_month":
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), nil
+	case "this_year":
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location()), nil
+	}
+
+	if match := relativeDatePattern.FindStringSubmatch(value); match != nil {
+		amount, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "d":
+			return today.AddDate(0, 0, -amount), nil
+		case "w":
+			return today.AddDate(0, 0, -7*amount), nil
+		case "m":
+			return today.AddDate(0, -amount, 0), nil
+		default:
+			return today.AddDate(-amount, 0, 0), nil
+		}
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+	}
+	return date, nil
+}
+
+func (rs smartRuleSet) matches(rom libraryRom, library smartLibrary) bool {
+	rules := rs.rules
+	trackerPath := trackerPathForRom(rom.Path)
+	filename := filepath.Base(rom.Path)
+
+	if len(rules.Platforms) > 0 && !matchesPlatform(trackerPath, rules.Platforms) {
+		return false
+	}
+
+	if len(rules.Keywords) > 0 && !slices.ContainsFunc(rules.Keywords, func(keyword string) bool {
+		return strings.Contains(strings.ToLower(filename), strings.ToLower(strings.TrimSpace(keyword)))
+	}) {
+		return false
+	}
+
+	if len(rules.Regions) > 0 && !matchesRegion(filename, rules.Regions) {
+		return false
+	}
+
+	if len(rules.BacklogStatus) > 0 && !slices.ContainsFunc(rules.BacklogStatus, func(status string) bool {
+		return strings.EqualFold(status, library.backlog[trackerPath])
+	}) {
+		return false
+	}
+
+	activity := romActivityFor(rom, library.activity)
+	if rules.NeverPlayed && activity.playTime > 0 {
+		return false
+	}
+
+	playHours := float64(activity.playTime) / 3600.0
+	if rules.MinPlayHours != nil && playHours < *rules.MinPlayHours {
+		return false
+	}
+	if rules.MaxPlayHours != nil && playHours > *rules.MaxPlayHours {
+		return false
+	}
+
+	lastPlayed := time.Unix(activity.lastPlayed, 0)
+	if !rs.playedAfter.IsZero() && (activity.lastPlayed == 0 || lastPlayed.Before(rs.playedAfter)) {
+		return false
+	}
+	if !rs.playedBefore.IsZero() && (activity.lastPlayed == 0 || !lastPlayed.Before(rs.playedBefore)) {
+		return false
+	}
+
+	if rules.HasArt != nil && (findPlayHistoryArt(rom.Path) != "") != *rules.HasArt {
+		return false
+	}
+
+	return true
+}
+
+// matchesPlatform accepts the full folder name, the name without its tag, or the bare tag, e.g. GBA.
+func matchesPlatform(trackerPath string, platforms []string) bool {
+	folder := strings.SplitN(filepath.ToSlash(trackerPath), "/", 2)[0]
+	tag := extractTag(folder)
+	bareTag := strings.Trim(tag, "()")
+	name := strings.TrimSpace(strings.Replace(folder, tag, "", 1))
+
+	return slices.ContainsFunc(platforms, func(platform string) bool {
+		platform = strings.TrimSpace(platform)
+		return strings.EqualFold(platform, folder) ||
+			strings.EqualFold(platform, name) ||
+			(bareTag != "" && strings.EqualFold(strings.Trim(platform, "()"), bareTag))
+	})
+}
+
+func matchesRegion(filename string, regions []string) bool {
+	for _, match := range romTagPattern.FindAllStringSubmatch(removeFileExtension(filename), -1) {
+		for _, tag := range strings.Split(match[1], ",") {
+			if slices.ContainsFunc(regions, func(region string) bool {
+				return strings.EqualFold(strings.TrimSpace(region), strings.TrimSpace(tag))
+			}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// romActivityFor totals the activity of a ROM, multi-disc folders add up the play of every disc.
+func romActivityFor(rom libraryRom, activity map[string]romActivity) romActivity {
+	trackerPath := trackerPathForRom(rom.Path)
+	if !rom.IsDirectory {
+		return activity[trackerPath]
+	}
+
+	var total romActivity
+	for path, discActivity := range activity {
+		if strings.HasPrefix(path, trackerPath+"/") {
+			total.playTime += discActivity.playTime
+			total.lastPlayed = max(total.lastPlayed, discActivity.lastPlayed)
+		}
+	}
+	return total
+}
+
+func activityByTrackerPath() map[string]romActivity {
+	logger := common.GetLoggerInstance()
+	activity := make(map[string]romActivity)
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return activity
+	}
+
+	rows, err := db.Query("SELECT rom.file_path, SUM(play_activity.play_time), MAX(play_activity.created_at) " +
+		"FROM rom JOIN play_activity ON rom.id = play_activity.rom_id " +
+		"GROUP BY rom.id")
+	if err != nil {
+		logger.Error("Failed to load play activity", zap.Error(err))
+		return activity
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var filePath string
+		var playTime int
+		var lastPlayed int64
+		if err := rows.Scan(&filePath, &playTime, &lastPlayed); err != nil {
+			logger.Error("Failed to read play activity", zap.Error(err))
+			continue
+		}
+
+		trackerPath := unarchivedTrackerPath(filePath)
+		existing := activity[trackerPath]
+		activity[trackerPath] = romActivity{
+			playTime:   existing.playTime + playTime,
+			lastPlayed: max(existing.lastPlayed, lastPlayed),
+		}
+	}
+
+	return activity
+}
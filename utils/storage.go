@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"encoding/json"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	"go.uber.org/zap"
+	"io/fs"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	storageCacheFilename = "storage_cache.json"
+	largestStorageItems  = 20
+)
+
+var (
+	storageMutex   sync.Mutex
+	storageRefresh chan struct{}
+)
+
+// GetCachedStorageReport returns the last computed storage report, if there is one.
+func GetCachedStorageReport() (models.StorageReport, bool) {
+	data, err := os.ReadFile(filepath.Join(GetDataDirectory(), storageCacheFilename))
+	if err != nil {
+		return models.StorageReport{}, false
+	}
+
+	var report models.StorageReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		common.GetLoggerInstance().Error("Failed to parse storage cache", zap.Error(err))
+		return models.StorageReport{}, false
+	}
+
+	return report, true
+}
+
+// RefreshStorageReport recomputes storage usage in the background. The returned channel is closed once the
+// cache has been rewritten; calling it while a refresh is running returns the running refresh.
+func RefreshStorageReport() <-chan struct{} {
+	storageMutex.Lock()
+	defer storageMutex.Unlock()
+
+	if storageRefresh != nil {
+		return storageRefresh
+	}
+
+	done := make(chan struct{})
+	storageRefresh = done
+
+	go func() {
+		defer func() {
+			storageMutex.Lock()
+			storageRefresh = nil
+			storageMutex.Unlock()
+			close(done)
+		}()
+
+		report := computeStorageReport()
+
+		cachePath := filepath.Join(GetDataDirectory(), storageCacheFilename)
+
+		// written next to the cache and renamed so the screen never reads a half written file
+		data, err := json.Marshal(report)
+		if err == nil {
+			err = os.WriteFile(cachePath+".tmp", data, defaultFilePerm)
+		}
+		if err == nil {
+			err = os.Rename(cachePath+".tmp", cachePath)
+		}
+
+		if err != nil {
+			common.GetLoggerInstance().Error("Failed to save storage cache", zap.Error(err))
+		}
+	}()
+
+	return done
+}
+
+func IsStorageReportRefreshing() bool {
+	storageMutex.Lock()
+	defer storageMutex.Unlock()
+
+	return storageRefresh != nil
+}
+
+func computeStorageReport() models.StorageReport {
+	logger := common.GetLoggerInstance()
+	report := models.StorageReport{GeneratedAt: time.Now()}
+
+	romDirectories, err := GetRomDirectories()
+	if err != nil {
+		logger.Error("Failed to list ROM directories", zap.Error(err))
+	}
+
+	for _, romDirectory := range romDirectories {
+		platform := folderUsage(romDirectory.Path, func(d fs.DirEntry) bool { return d.Name() == ".media" })
+		platform.Name = romDirectory.DisplayName
+		platform.Kind = models.StorageKindPlatform
+		platform.Largest = largestRoms(romDirectory.Path)
+		report.Entries = append(report.Entries, platform)
+
+		media := mediaUsage(romDirectory.Path)
+		if media.FileCount > 0 {
+			media.Name = romDirectory.DisplayName
+			report.Entries = append(report.Entries, media)
+		}
+	}
+
+	archives, err := GetArchiveFileListBasic()
+	if err != nil {
+		logger.Error("Failed to list archives", zap.Error(err))
+	}
+
+	for _, archive := range archives {
+		archiveRoot := GetArchiveRoot(archive)
+		usage := folderUsage(archiveRoot, nil)
+		usage.Name = CleanArchiveName(archive)
+		usage.Kind = models.StorageKindArchive
+		usage.Largest = largestRoms(archiveRoot)
+		report.Entries = append(report.Entries, usage)
+	}
+
+	if saveDirectories, err := GetFileList(GetSaveFileDirectory()); err == nil {
+		for _, saveDirectory := range saveDirectories {
+			if !saveDirectory.IsDir() {
+				continue
+			}
+
+			usage := folderUsage(filepath.Join(GetSaveFileDirectory(), saveDirectory.Name()), nil)
+			usage.Name = saveDirectory.Name()
+			usage.Kind = models.StorageKindSaves
+			report.Entries = append(report.Entries, usage)
+		}
+	}
+
+	for _, entry := range report.Entries {
+		report.Total += entry.Size
+	}
+
+	slices.SortStableFunc(report.Entries, func(a, b models.StorageUsage) int {
+		switch {
+		case a.Size > b.Size:
+			return -1
+		case a.Size < b.Size:
+			return 1
+		}
+		return 0
+	})
+
+	return report
+}
+
+// folderUsage totals a directory tree, skipping directories matched by skip, and keeps the largest files.
+func folderUsage(path string, skip func(d fs.DirEntry) bool) models.StorageUsage {
+	usage := models.StorageUsage{Path: path}
+
+	_ = filepath.WalkDir(path, func(current string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			if current != path && skip != nil && skip(d) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		usage.Size += info.Size()
+		usage.FileCount++
+		usage.Largest = keepLargest(usage.Largest, models.StorageItem{Path: current, Size: info.Size()})
+		return nil
+	})
+
+	return usage
+}
+
+func mediaUsage(platformPath string) models.StorageUsage {
+	media := models.StorageUsage{Kind: models.StorageKindMedia, Path: filepath.Join(platformPath, ".media")}
+
+	_ = filepath.WalkDir(platformPath, func(current string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || d.Name() != ".media" {
+			return nil
+		}
+
+		usage := folderUsage(current, nil)
+		media.Size += usage.Size
+		media.FileCount += usage.FileCount
+		for _, item := range usage.Largest {
+			media.Largest = keepLargest(media.Largest, item)
+		}
+
+		return filepath.SkipDir
+	})
+
+	return media
+}
+
+func largestRoms(path string) []models.StorageItem {
+	roms, err := walkRoms(path, false)
+	if err != nil {
+		return nil
+	}
+
+	var largest []models.StorageItem
+	for _, rom := range roms {
+		largest = keepLargest(largest, models.StorageItem{Path: rom.Path, Size: rom.Size})
+	}
+	return largest
+}
+
+func keepLargest(largest []models.StorageItem, item models.StorageItem) []models.StorageItem {
+	index, _ := slices.BinarySearchFunc(largest, item.Size, func(existing models.StorageItem, size int64) int {
+		switch {
+		case existing.Size > size:
+			return -1
+		case existing.Size < size:
+			return 1
+		}
+		return 0
+	})
+
+	if index >= largestStorageItems {
+		return largest
+	}
+
+	largest = slices.Insert(largest, index, item)
+	if len(largest) > largestStorageItems {
+		largest = largest[:largestStorageItems]
+	}
+	return largest
+}
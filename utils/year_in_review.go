@@ -0,0 +1,244 @@
+package utils
+
+import (
+	"cmp"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	yearInReviewTopGames    = 10
+	yearInReviewTopConsoles = 5
+)
+
+// AvailableReviewYears lists the years with any play activity, newest first.
+func AvailableReviewYears() ([]int, error) {
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT DISTINCT STRFTIME('%Y', DATETIME(created_at, 'unixepoch', 'localtime')) AS year " +
+		"FROM play_activity " +
+		"WHERE play_time > 0 " +
+		"ORDER BY year DESC")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load play history years: %w", err)
+	}
+	defer rows.Close()
+
+	var years []int
+	for rows.Next() {
+		var year string
+		if err := rows.Scan(&year); err != nil {
+			return nil, fmt.Errorf("failed to load play history years: %w", err)
+		}
+
+		parsed, err := strconv.Atoi(year)
+		if err != nil {
+			continue
+		}
+		years = append(years, parsed)
+	}
+
+	return years, rows.Err()
+}
+
+// GenerateYearInReview builds the summary of a calendar year in local time. Totals and rankings come
+// from GenerateCurrentGameStats so they line up with the play history screens.
+func GenerateYearInReview(year int) (models.YearInReview, error) {
+	review := models.YearInReview{Year: year}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	query := models.PlayHistoryQuery{Start: start, End: start.AddDate(1, 0, 0)}
+
+	gamePlayMap, consolePlayMap, totalPlay, err := GenerateCurrentGameStats(query)
+	if err != nil {
+		return review, err
+	}
+
+	review.TotalPlayTime = totalPlay
+
+	var games []models.YearInReviewGame
+	for console, aggregates := range gamePlayMap {
+		for _, aggregate := range aggregates {
+			games = append(games, models.YearInReviewGame{
+				Name:        aggregate.Name,
+				Console:     console,
+				Path:        aggregate.Path,
+				PlayTime:    aggregate.PlayTimeTotal,
+				PlayCount:   aggregate.PlayCountTotal,
+				FirstPlayed: aggregate.FirstPlayedTime,
+			})
+			review.SessionCount += aggregate.PlayCountTotal
+		}
+	}
+	review.GamesPlayed = len(games)
+
+	slices.SortFunc(games, func(a, b models.YearInReviewGame) int {
+		if a.PlayTime != b.PlayTime {
+			return cmp.Compare(b.PlayTime, a.PlayTime)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	review.TopGames = games[:min(len(games), yearInReviewTopGames)]
+	for i := range review.TopGames {
+		review.TopGames[i].ArtPath = findPlayHistoryArt(review.TopGames[i].Path)
+	}
+
+	consoles := slices.SortedStableFunc(maps.Keys(consolePlayMap), func(a, b string) int {
+		return cmp.Compare(consolePlayMap[b], consolePlayMap[a])
+	})
+	for _, console := range consoles[:min(len(consoles), yearInReviewTopConsoles)] {
+		review.TopConsoles = append(review.TopConsoles, models.YearInReviewConsole{
+			Name:     console,
+			PlayTime: consolePlayMap[console],
+		})
+	}
+
+	if review.SessionCount == 0 {
+		return review, nil
+	}
+
+	if review.LongestSession, err = findYearSession(query, "play_activity.play_time DESC"); err != nil {
+		return review, err
+	}
+
+	if review.FirstSession, err = findYearSession(query, "play_activity.created_at ASC"); err != nil {
+		return review, err
+	}
+
+	if review.LastSession, err = findYearSession(query, "play_activity.created_at DESC"); err != nil {
+		return review, err
+	}
+
+	review.NewGames, err = findNewGames(query)
+
+	return review, err
+}
+
+// findYearSession returns the first session of the year under the given ordering,
+// orderBy is always one of the fixed orderings above and never user input.
+func findYearSession(query models.PlayHistoryQuery, orderBy string) (models.YearInReviewSession, error) {
+	var session models.YearInReviewSession
+
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return session, err
+	}
+
+	whereClause, args := compilePlayHistoryQuery(query)
+
+	var name string
+	var filePath string
+	var createdAt int64
+	err = db.QueryRow("SELECT rom.name, rom.file_path, play_activity.play_time, play_activity.created_at "+
+		"FROM rom "+
+		"JOIN play_activity "+
+		"ON rom.id = play_activity.rom_id "+
+		whereClause+
+		"ORDER BY "+orderBy+" "+
+		"LIMIT 1", args...).Scan(&name, &filePath, &session.PlayTime, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, nil
+	} else if err != nil {
+		return session, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+
+	session.Name, _, _ = extractMultiDiscName(name, filePath)
+	session.Console = extractPlayConsoleName(filePath)
+	session.StartedAt = time.Unix(createdAt, 0)
+
+	return session, nil
+}
+
+// findNewGames lists the games whose very first session falls inside the query's date range.
+// Discs of a multi-disc game count as one game, started when its first disc was.
+func findNewGames(query models.PlayHistoryQuery) ([]models.YearInReviewGame, error) {
+	db, err := getGameTrackerDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT rom.name, rom.file_path, MIN(play_activity.created_at) AS first_played_at " +
+		"FROM rom " +
+		"JOIN play_activity " +
+		"ON rom.id = play_activity.rom_id " +
+		"GROUP BY rom.id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+	defer rows.Close()
+
+	firstPlayed := make(map[string]models.YearInReviewGame)
+	for rows.Next() {
+		var name string
+		var filePath string
+		var firstPlayedAt int64
+		if err := rows.Scan(&name, &filePath, &firstPlayedAt); err != nil {
+			return nil, fmt.Errorf("failed to load game tracker data: %w", err)
+		}
+
+		gameName, gamePath, _ := extractMultiDiscName(name, filePath)
+		console := extractPlayConsoleName(filePath)
+		key := console + "/" + gameName
+
+		if existing, ok := firstPlayed[key]; ok && !time.Unix(firstPlayedAt, 0).Before(existing.FirstPlayed) {
+			continue
+		}
+
+		firstPlayed[key] = models.YearInReviewGame{
+			Name:        gameName,
+			Console:     console,
+			Path:        gamePath,
+			FirstPlayed: time.Unix(firstPlayedAt, 0),
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load game tracker data: %w", err)
+	}
+
+	var newGames []models.YearInReviewGame
+	for _, game := range firstPlayed {
+		if game.FirstPlayed.Before(query.Start) || !game.FirstPlayed.Before(query.End) {
+			continue
+		}
+		newGames = append(newGames, game)
+	}
+
+	slices.SortFunc(newGames, func(a, b models.YearInReviewGame) int {
+		return a.FirstPlayed.Compare(b.FirstPlayed)
+	})
+
+	return newGames, nil
+}
+
+// findPlayHistoryArt looks for the box art of a play history path, which is the ROM itself
+// or the folder holding the discs of a multi-disc game.
+func findPlayHistoryArt(gamePath string) string {
+	if gamePath == "" {
+		return ""
+	}
+
+	name := filepath.Base(gamePath)
+	if info, err := os.Stat(gamePath); err != nil || !info.IsDir() {
+		name = removeFileExtension(name)
+	}
+
+	artPath := filepath.Join(filepath.Dir(gamePath), ".media", name+".png")
+	if !DoesFileExists(artPath) {
+		return ""
+	}
+
+	return artPath
+}
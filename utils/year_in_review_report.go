@@ -0,0 +1,347 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/disintegration/imaging"
+	"html/template"
+	"image"
+	"image/draw"
+	"nextui-game-manager/models"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	yearInReviewDirectory   = "year_in_review"
+	yearInReviewGridColumns = 5
+	yearInReviewArtWidth    = 90
+	yearInReviewArtHeight   = 100
+	yearInReviewRowHeight   = 150
+	yearInReviewNewPerPage  = 44
+)
+
+// RenderYearInReviewPages draws the review as a sequence of screen sized PNGs and returns their paths in order.
+// Pages without content, like a year with no new games, are left out.
+func RenderYearInReviewPages(review models.YearInReview) ([]string, error) {
+	pageDirectory := filepath.Join(GetDataDirectory(), yearInReviewDirectory)
+	_ = os.RemoveAll(pageDirectory)
+
+	renderers := []func(*chartCanvas, models.YearInReview){renderYearInReviewOverview}
+	if len(review.TopGames) > 0 {
+		renderers = append(renderers, renderYearInReviewTopGames)
+	}
+	if len(review.NewGames) > 0 {
+		renderers = append(renderers, renderYearInReviewNewGames)
+	}
+
+	var pages []string
+	for i, render := range renderers {
+		cc := newChartCanvas()
+		cc.text(chartMargin, 16, fmt.Sprintf("%d Year in Review", review.Year), chartText)
+		cc.rightText(chartWidth-chartMargin, 16, fmt.Sprintf("%d / %d", i+1, len(renderers)), chartDimText)
+
+		render(cc, review)
+
+		pagePath := filepath.Join(pageDirectory, fmt.Sprintf("page_%d.png", i+1))
+		if err := cc.save(pagePath); err != nil {
+			return nil, fmt.Errorf("failed to render year in review: %w", err)
+		}
+		pages = append(pages, pagePath)
+	}
+
+	return pages, nil
+}
+
+func renderYearInReviewOverview(cc *chartCanvas, review models.YearInReview) {
+	cc.largeText(chartMargin, 64, fmt.Sprintf("%.1f Hours", float64(review.TotalPlayTime)/3600.0), chartAccent, 3)
+	cc.text(chartMargin, 84, fmt.Sprintf("%d sessions across %d games", review.SessionCount, review.GamesPlayed), chartDimText)
+
+	cc.text(chartMargin, 112, "Top Platforms", chartText)
+	barLeft := chartMargin + 22*chartGlyphWidth
+	barWidth := chartWidth - chartMargin - 7*chartGlyphWidth - barLeft
+	for i, console := range review.TopConsoles {
+		baseline := 130 + i*16
+		hours := fmt.Sprintf("%.1fH", float64(console.PlayTime)/3600.0)
+		cc.text(chartMargin, baseline, truncateChartText(console.Name, 21), chartDimText)
+
+		width := 1
+		if review.TopConsoles[0].PlayTime > 0 {
+			width = max(1, barWidth*console.PlayTime/review.TopConsoles[0].PlayTime)
+		}
+		cc.rect(barLeft, baseline-10, width, 11, chartAccent)
+		cc.rightText(chartWidth-chartMargin, baseline, hours, chartText)
+	}
+
+	highlights := []struct {
+		label   string
+		session models.YearInReviewSession
+		detail  string
+	}{
+		{"Longest Session", review.LongestSession, ConvertSecondsToHumanReadableAbbreviated(review.LongestSession.PlayTime)},
+		{"First Game Of The Year", review.FirstSession, review.FirstSession.StartedAt.Format("Jan 2")},
+		{"Last Game Played", review.LastSession, review.LastSession.StartedAt.Format("Jan 2")},
+	}
+
+	for i, highlight := range highlights {
+		top := 228 + i*46
+		cc.text(chartMargin, top, highlight.label, chartDimText)
+		cc.text(chartMargin, top+16, truncateChartText(highlight.session.Name, 70-len(highlight.detail)-1), chartText)
+		cc.rightText(chartWidth-chartMargin, top+16, highlight.detail, chartAccent)
+		cc.text(chartMargin, top+30, truncateChartText(highlight.session.Console, 70), chartDimText)
+	}
+}
+
+func renderYearInReviewTopGames(cc *chartCanvas, review models.YearInReview) {
+	cc.text(chartMargin, 36, fmt.Sprintf("Top %d Games", len(review.TopGames)), chartText)
+
+	cellWidth := (chartWidth - 2*chartMargin) / yearInReviewGridColumns
+	for i, game := range review.TopGames {
+		left := chartMargin + (i%yearInReviewGridColumns)*cellWidth
+		top := 48 + (i/yearInReviewGridColumns)*yearInReviewRowHeight
+		artLeft := left + (cellWidth-yearInReviewArtWidth)/2
+
+		if game.ArtPath != "" {
+			cc.picture(artLeft, top, yearInReviewArtWidth, yearInReviewArtHeight, game.ArtPath)
+		} else {
+			cc.rect(artLeft, top, yearInReviewArtWidth, yearInReviewArtHeight, chartEmptyCell)
+			cc.text(artLeft+(yearInReviewArtWidth-6*chartGlyphWidth)/2, top+yearInReviewArtHeight/2+4, "No Art", chartDimText)
+		}
+
+		maxChars := cellWidth/chartGlyphWidth - 1
+		cc.text(left, top+yearInReviewArtHeight+14, truncateChartText(fmt.Sprintf("%d. %s", i+1, game.Name), maxChars), chartText)
+		cc.text(left, top+yearInReviewArtHeight+28, fmt.Sprintf("%.1fH", float64(game.PlayTime)/3600.0), chartDimText)
+	}
+}
+
+func renderYearInReviewNewGames(cc *chartCanvas, review models.YearInReview) {
+	cc.text(chartMargin, 36, fmt.Sprintf("%d New Games Started", len(review.NewGames)), chartText)
+
+	games := review.NewGames
+	remaining := 0
+	if len(games) > yearInReviewNewPerPage {
+		remaining = len(games) - yearInReviewNewPerPage + 1
+		games = games[:yearInReviewNewPerPage-1]
+	}
+
+	rows := yearInReviewNewPerPage / 2
+	columnWidth := (chartWidth - 2*chartMargin) / 2
+	for i, game := range games {
+		left := chartMargin + (i/rows)*columnWidth
+		baseline := 56 + (i%rows)*chartLineHeight
+		cc.text(left, baseline, game.FirstPlayed.Format("Jan 02"), chartDimText)
+		cc.text(left+7*chartGlyphWidth, baseline, truncateChartText(game.Name, columnWidth/chartGlyphWidth-8), chartText)
+	}
+
+	if remaining > 0 {
+		cc.text(chartMargin+columnWidth, 56+(rows-1)*chartLineHeight, fmt.Sprintf("...and %d more", remaining), chartDimText)
+	}
+}
+
+// ExportYearInReview writes a collage of the rendered pages plus Markdown and HTML versions of the
+// report to dir, returning the written files. The documents reference the collage by its file name.
+func ExportYearInReview(review models.YearInReview, pages []string, dir string) ([]string, error) {
+	if err := EnsureDirectoryExists(dir); err != nil {
+		return nil, err
+	}
+
+	base := fmt.Sprintf("year_in_review_%d", review.Year)
+	collagePath := filepath.Join(dir, base+".png")
+	if err := saveYearInReviewCollage(pages, collagePath); err != nil {
+		return nil, err
+	}
+
+	markdownPath := filepath.Join(dir, base+".md")
+	if err := os.WriteFile(markdownPath, []byte(yearInReviewMarkdown(review, filepath.Base(collagePath))), defaultFilePerm); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", markdownPath, err)
+	}
+
+	htmlPath := filepath.Join(dir, base+".html")
+	htmlFile, err := os.Create(htmlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", htmlPath, err)
+	}
+	defer htmlFile.Close()
+
+	if err := yearInReviewHTML.Execute(htmlFile, yearInReviewDocument(review, filepath.Base(collagePath))); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", htmlPath, err)
+	}
+
+	return []string{collagePath, markdownPath, htmlPath}, nil
+}
+
+// saveYearInReviewCollage stacks the pages into one tall image.
+func saveYearInReviewCollage(pages []string, collagePath string) error {
+	var images []image.Image
+	height := 0
+	width := 0
+	for _, page := range pages {
+		img, err := imaging.Open(page)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", page, err)
+		}
+		images = append(images, img)
+		height += img.Bounds().Dy()
+		width = max(width, img.Bounds().Dx())
+	}
+
+	collage := imaging.New(width, height, chartBackground)
+	top := 0
+	for _, img := range images {
+		draw.Draw(collage, image.Rect(0, top, img.Bounds().Dx(), top+img.Bounds().Dy()), img, img.Bounds().Min, draw.Src)
+		top += img.Bounds().Dy()
+	}
+
+	return imaging.Save(collage, collagePath)
+}
+
+type yearInReviewRow struct {
+	Rank     int
+	Name     string
+	Console  string
+	Hours    string
+	Sessions int
+	Date     string
+}
+
+type yearInReviewHighlight struct {
+	Label   string
+	Name    string
+	Console string
+	Detail  string
+}
+
+// yearInReviewView is the review with everything already formatted, shared by the Markdown and HTML output.
+type yearInReviewView struct {
+	Year        int
+	Image       string
+	Hours       string
+	Sessions    int
+	Games       int
+	TopGames    []yearInReviewRow
+	TopConsoles []yearInReviewRow
+	Highlights  []yearInReviewHighlight
+	NewGames    []yearInReviewRow
+}
+
+func yearInReviewDocument(review models.YearInReview, imageName string) yearInReviewView {
+	view := yearInReviewView{
+		Year:     review.Year,
+		Image:    imageName,
+		Hours:    fmt.Sprintf("%.1f", float64(review.TotalPlayTime)/3600.0),
+		Sessions: review.SessionCount,
+		Games:    review.GamesPlayed,
+		Highlights: []yearInReviewHighlight{
+			{"Longest Session", review.LongestSession.Name, review.LongestSession.Console,
+				ConvertSecondsToHumanReadableAbbreviated(review.LongestSession.PlayTime)},
+			{"First Game Of The Year", review.FirstSession.Name, review.FirstSession.Console,
+				review.FirstSession.StartedAt.Format("January 2")},
+			{"Last Game Played", review.LastSession.Name, review.LastSession.Console,
+				review.LastSession.StartedAt.Format("January 2")},
+		},
+	}
+
+	for i, game := range review.TopGames {
+		view.TopGames = append(view.TopGames, yearInReviewRow{
+			Rank:     i + 1,
+			Name:     game.Name,
+			Console:  game.Console,
+			Hours:    fmt.Sprintf("%.1f", float64(game.PlayTime)/3600.0),
+			Sessions: game.PlayCount,
+		})
+	}
+
+	for i, console := range review.TopConsoles {
+		view.TopConsoles = append(view.TopConsoles, yearInReviewRow{
+			Rank:  i + 1,
+			Name:  console.Name,
+			Hours: fmt.Sprintf("%.1f", float64(console.PlayTime)/3600.0),
+		})
+	}
+
+	for _, game := range review.NewGames {
+		view.NewGames = append(view.NewGames, yearInReviewRow{
+			Name:    game.Name,
+			Console: game.Console,
+			Date:    game.FirstPlayed.Format("January 2"),
+		})
+	}
+
+	return view
+}
+
+func yearInReviewMarkdown(review models.YearInReview, imageName string) string {
+	view := yearInReviewDocument(review, imageName)
+	cell := func(s string) string {
+		return strings.ReplaceAll(s, "|", "\\|")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %d Year in Review\n\n", view.Year)
+	fmt.Fprintf(&sb, "![%d Year in Review](%s)\n\n", view.Year, view.Image)
+	fmt.Fprintf(&sb, "**%s hours** played over %d sessions across %d games.\n\n", view.Hours, view.Sessions, view.Games)
+
+	sb.WriteString("## Top Games\n\n| # | Game | Platform | Hours | Sessions |\n|---|---|---|---|---|\n")
+	for _, row := range view.TopGames {
+		fmt.Fprintf(&sb, "| %d | %s | %s | %s | %d |\n", row.Rank, cell(row.Name), cell(row.Console), row.Hours, row.Sessions)
+	}
+
+	sb.WriteString("\n## Top Platforms\n\n| # | Platform | Hours |\n|---|---|---|\n")
+	for _, row := range view.TopConsoles {
+		fmt.Fprintf(&sb, "| %d | %s | %s |\n", row.Rank, cell(row.Name), row.Hours)
+	}
+
+	sb.WriteString("\n## Highlights\n\n")
+	for _, highlight := range view.Highlights {
+		fmt.Fprintf(&sb, "- **%s:** %s (%s) - %s\n", highlight.Label, highlight.Name, highlight.Console, highlight.Detail)
+	}
+
+	if len(view.NewGames) > 0 {
+		fmt.Fprintf(&sb, "\n## %d New Games Started\n\n", len(view.NewGames))
+		for _, row := range view.NewGames {
+			fmt.Fprintf(&sb, "- %s: %s (%s)\n", row.Date, row.Name, row.Console)
+		}
+	}
+
+	return sb.String()
+}
+
+var yearInReviewHTML = template.Must(template.New("year_in_review").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Year}} Year in Review</title>
+<style>
+body { background: #101010; color: #f0f0f0; font-family: sans-serif; max-width: 1024px; margin: 0 auto; padding: 16px; }
+h1, h2 { color: #3cd08c; }
+img { width: 100%; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 4px 8px; border-bottom: 1px solid #262626; }
+.dim { color: #909090; }
+</style>
+</head>
+<body>
+<h1>{{.Year}} Year in Review</h1>
+<img src="{{.Image}}" alt="{{.Year}} Year in Review">
+<p><strong>{{.Hours}} hours</strong> played over {{.Sessions}} sessions across {{.Games}} games.</p>
+<h2>Top Games</h2>
+<table>
+<tr><th>#</th><th>Game</th><th>Platform</th><th>Hours</th><th>Sessions</th></tr>
+{{range .TopGames}}<tr><td>{{.Rank}}</td><td>{{.Name}}</td><td class="dim">{{.Console}}</td><td>{{.Hours}}</td><td>{{.Sessions}}</td></tr>
+{{end}}</table>
+<h2>Top Platforms</h2>
+<table>
+<tr><th>#</th><th>Platform</th><th>Hours</th></tr>
+{{range .TopConsoles}}<tr><td>{{.Rank}}</td><td>{{.Name}}</td><td>{{.Hours}}</td></tr>
+{{end}}</table>
+<h2>Highlights</h2>
+<ul>
+{{range .Highlights}}<li><strong>{{.Label}}:</strong> {{.Name}} <span class="dim">({{.Console}})</span> - {{.Detail}}</li>
+{{end}}</ul>
+{{if .NewGames}}<h2>{{len .NewGames}} New Games Started</h2>
+<ul>
+{{range .NewGames}}<li><span class="dim">{{.Date}}</span> {{.Name}} <span class="dim">({{.Console}})</span></li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))